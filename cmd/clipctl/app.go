@@ -81,6 +81,7 @@ func (a *app) executeCommand(cmd cli.Command, commandName string) int {
 	ctx := a.setupSignalHandling()
 
 	client := client.NewClient(a.config.socketPath)
+	client.SetProfile(a.config.profile)
 
 	if err := a.pingDaemon(ctx, client); err != nil {
 		return exitDaemonNotRunning
@@ -14,6 +14,7 @@ const (
 type config struct {
 	socketPath  string
 	timeout     time.Duration
+	profile     string
 	showVersion bool
 	verbose     bool
 }
@@ -23,6 +24,7 @@ func parseFlags() *config {
 
 	flag.StringVar(&cfg.socketPath, "socket", defaultSocketPath, "Path to the clipd socket")
 	flag.DurationVar(&cfg.timeout, "timeout", defaultTimeout, "Request timeout duration")
+	flag.StringVar(&cfg.profile, "profile", "", "Profile to query (default: the active profile)")
 	flag.BoolVar(&cfg.showVersion, "version", false, "Show version information")
 	flag.BoolVar(&cfg.verbose, "v", false, "Verbose output")
 
@@ -16,6 +16,7 @@ func printUsage(registry *cli.Registry) {
 	fmt.Printf("%sOPTIONS:%s\n", colorBold, colorReset)
 	fmt.Printf("  %s--socket%s <path>     Path to the clipd socket (default: %s)\n", colorCyan, colorReset, defaultSocketPath)
 	fmt.Printf("  %s--timeout%s <duration> Request timeout duration (default: %s)\n", colorCyan, colorReset, defaultTimeout)
+	fmt.Printf("  %s--profile%s <name>    Profile to query (default: the active profile)\n", colorCyan, colorReset)
 	fmt.Printf("  %s--version%s           Show version information\n", colorCyan, colorReset)
 	fmt.Printf("  %s-v%s                  Verbose output\n", colorCyan, colorReset)
 	fmt.Println()
@@ -10,7 +10,16 @@ func createRegistry() *cli.Registry {
 	registry.Register(&commands.ListCommand{})
 	registry.Register(&commands.SearchCommand{})
 	registry.Register(&commands.GetCommand{})
+	registry.Register(&commands.CopyCommand{})
 	registry.Register(&commands.DeleteCommand{})
 	registry.Register(&commands.StatsCommand{})
+	registry.Register(&commands.PauseCommand{})
+	registry.Register(&commands.ResumeCommand{})
+	registry.Register(&commands.MaintenanceCommand{})
+	registry.Register(&commands.ProfileCommand{})
+	registry.Register(&commands.RulesCommand{})
+	registry.Register(&commands.AnalyzeCommand{})
+	registry.Register(&commands.TransformCommand{})
+	registry.Register(&commands.VaultCommand{})
 	return registry
 }
@@ -0,0 +1,52 @@
+// Command clipd-feed records its standard input as one clipboard history
+// entry, through the pipe that clipd reads when started with --feed-fifo:
+//
+//	git log -1 --format=%H | clipd-feed
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+)
+
+func main() {
+	fifo := flag.String("fifo", "/tmp/clipd.feed", "Pipe that clipd reads (its --feed-fifo)")
+	flag.Parse()
+
+	if err := feed(*fifo, os.Stdin); err != nil {
+		fmt.Fprintf(os.Stderr, "clipd-feed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func feed(path string, r io.Reader) error {
+	// Read everything first so that a slow producer does not hold the pipe
+	// open and block other feeds.
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading input: %w", err)
+	}
+	if len(content) == 0 {
+		return errors.New("nothing to feed")
+	}
+
+	// Without O_NONBLOCK the open would wait forever when clipd is not
+	// reading the pipe.
+	f, err := os.OpenFile(path, os.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if errors.Is(err, syscall.ENXIO) || errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("clipd is not reading %s (start it with --feed-fifo %s)", path, path)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return fmt.Errorf("writing to %s: %w", path, err)
+	}
+	return f.Close()
+}
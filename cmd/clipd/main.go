@@ -1,17 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/geodask/clipboard-manager/internal/analyzer"
 	"github.com/geodask/clipboard-manager/internal/api"
+	"github.com/geodask/clipboard-manager/internal/capture"
 	"github.com/geodask/clipboard-manager/internal/config"
 	"github.com/geodask/clipboard-manager/internal/daemon"
+	"github.com/geodask/clipboard-manager/internal/domain"
+	"github.com/geodask/clipboard-manager/internal/ignore"
 	"github.com/geodask/clipboard-manager/internal/logger"
 	"github.com/geodask/clipboard-manager/internal/monitor"
 	"github.com/geodask/clipboard-manager/internal/service"
 	"github.com/geodask/clipboard-manager/internal/storage"
+	"github.com/geodask/clipboard-manager/internal/vault"
 )
 
 func main() {
@@ -27,23 +32,86 @@ func main() {
 		os.Exit(1)
 	}
 
-	logger.Info("starting clipboard manager daemon", "db_path", cfg.Database.Path, "socket_path", cfg.API.SocketPath, "poll_interval", cfg.Daemon.PollInterval)
+	logger.Info("starting clipboard manager daemon", "db_path", cfg.Database.Path, "socket_path", cfg.API.SocketPath, "monitor", cfg.Monitor.Backend, "clipboard", cfg.Monitor.Clipboard, "selections", cfg.Monitor.Selections)
 
-	storage, err := storage.NewSQLiteStorage(cfg.Database.Path)
+	storage, err := storage.NewSQLiteStorage(cfg.Database)
 	if err != nil {
 		logger.Error("failed to initialize storage", "error", err)
 		return
 	}
 	defer storage.Close()
 
-	monitor := monitor.NewPollingMonitor()
-	analyzer := analyzer.NewSimpleAnalyzer()
+	clipboard, err := monitor.NewClipboard(cfg.Monitor, domain.SelectionClipboard)
+	if err != nil {
+		logger.Error("failed to initialize clipboard backend", "error", err)
+		return
+	}
+	sources, err := monitor.NewSources(cfg.Sources, cfg.Monitor)
+	if err != nil {
+		logger.Error("failed to initialize capture sources", "error", err)
+		return
+	}
+
+	monitor, err := monitor.New(cfg.Monitor, clipboard)
+	if err != nil {
+		logger.Error("failed to initialize clipboard monitor", "error", err)
+		return
+	}
+	filter, err := capture.New(cfg.Capture, cfg.Ignore.MaxLength)
+	if err != nil {
+		logger.Error("failed to load capture filter", "error", err)
+		return
+	}
+	analyzer, err := analyzer.NewDefaultPipeline(cfg.Analyzer)
+	if err != nil {
+		logger.Error("failed to load analyzer rules", "error", err)
+		return
+	}
+
+	scope := func(profile string) service.Storage {
+		return storage.WithProfile(profile)
+	}
 
 	service := service.NewClipboardService(storage, analyzer)
+	if err := service.EnableProfiles(context.Background(), storage, scope); err != nil {
+		logger.Error("failed to initialize profiles", "error", err)
+		return
+	}
+
+	ignoreRules, err := ignore.New(cfg.Ignore)
+	if err != nil {
+		logger.Error("failed to load ignore rules", "error", err)
+		return
+	}
+	service.EnableIgnoreRules(ignoreRules)
+	service.EnableClipboard(monitor)
+	service.EnableMonitorStatus(monitor)
+
+	if cfg.Monitor.PersistPause {
+		if err := service.EnablePauseStore(context.Background(), storage); err != nil {
+			logger.Error("failed to restore pause state", "error", err)
+			return
+		}
+		switch pause := service.PauseState(); {
+		case pause.Paused && pause.Until.IsZero():
+			logger.Info("capture paused until resumed")
+		case pause.Paused:
+			logger.Info("capture paused", "until", pause.Until)
+		}
+	}
+
+	if cfg.Vault.Enabled {
+		service.EnableVault(vault.New(cfg.Vault))
+		logger.Info("sensitive entry vault enabled", "ttl", cfg.Vault.TTL, "max_entries", cfg.Vault.MaxEntries)
+	}
 
 	apiServer := api.NewServer(service, cfg.API, logger)
 
 	daemon := daemon.NewDaemon(monitor, service, apiServer, logger, cfg.Daemon)
+	for _, source := range sources {
+		daemon.AddSource(source)
+	}
+	daemon.SetCaptureFilter(filter)
 
 	if err := daemon.Start(); err != nil {
 		logger.Error("daemon stopped with error", "error", err)
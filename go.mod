@@ -5,11 +5,12 @@ go 1.25.3
 require (
 	github.com/atotto/clipboard v0.1.4
 	golang.org/x/sync v0.17.0
+	golang.org/x/text v0.30.0
 )
 
-require github.com/mattn/go-sqlite3 v1.14.32
-
 require (
-	github.com/go-chi/chi/v5 v5.2.3 // indirect
-	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
+	github.com/go-chi/chi/v5 v5.2.3
+	github.com/mattn/go-sqlite3 v1.14.32
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -1,82 +1,183 @@
 package analyzer
 
 import (
-	"regexp"
-	"strings"
+	"context"
+	"fmt"
+	"sync"
+	"time"
 
+	"github.com/geodask/clipboard-manager/internal/config"
 	"github.com/geodask/clipboard-manager/internal/domain"
 )
 
+// Analyzer is one stage of a Pipeline. Analyze must not modify the entry
+// and should give up when ctx is done.
 type Analyzer interface {
-	Analyze(entry *domain.ClipboardEntry) *domain.Analysis
+	Name() string
+	Analyze(ctx context.Context, entry *domain.ClipboardEntry) *domain.Analysis
 }
 
-type SimpleAnalyzer struct {
-	passwordPattern *regexp.Regexp
-	tokenPattern    *regexp.Regexp
-	apiKeyPattern   *regexp.Regexp
+// SensitiveAnalyzer finds secrets and PII with pattern rules and built-in
+// detectors, and decides whether the entry may be stored.
+type SensitiveAnalyzer struct {
+	rulesFile     string
+	defaultAction domain.RuleAction
+	detectors     []detector
+	shortTTL      time.Duration
+
+	mu    sync.RWMutex
+	rules []compiledRule
 }
 
-func NewSimpleAnalyzer() *SimpleAnalyzer {
-	return &SimpleAnalyzer{
-		passwordPattern: regexp.MustCompile(`(?i)(password|passwd|pwd)\s*[:=]\s*\S+`),
-		tokenPattern:    regexp.MustCompile(`(?i)(token|bearer)\s*[:=]?\s*[A-Za-z0-9_-]{20,}`),
-		apiKeyPattern:   regexp.MustCompile(`(?i)(api[_-]?key|secret[_-]?key)\s*[:=]\s*\S+`),
+func NewSensitiveAnalyzer(cfg config.AnalyzerConfig) (*SensitiveAnalyzer, error) {
+	var action domain.RuleAction
+	switch cfg.SensitiveMode {
+	case "", "block":
+		action = domain.ActionBlock
+	case "redact":
+		action = domain.ActionRedact
+	default:
+		return nil, fmt.Errorf("unknown sensitive mode %q (want block or redact)", cfg.SensitiveMode)
 	}
-}
 
-func (a *SimpleAnalyzer) Analyze(entry *domain.ClipboardEntry) *domain.Analysis {
-	content := entry.Content
+	detectors, err := newDetectors(cfg, action)
+	if err != nil {
+		return nil, err
+	}
 
-	if a.passwordPattern.MatchString(content) {
-		return &domain.Analysis{
-			Type:        domain.ContentTypeText,
-			IsSensitive: true,
-			Reason:      "contains password",
-		}
+	a := &SensitiveAnalyzer{
+		rulesFile:     cfg.RulesFile,
+		defaultAction: action,
+		detectors:     detectors,
+		shortTTL:      cfg.ShortTTL,
 	}
 
-	if a.tokenPattern.MatchString(content) {
-		return &domain.Analysis{
-			Type:        domain.ContentTypeText,
-			IsSensitive: true,
-			Reason:      "contains token",
-		}
+	if err := a.ReloadRules(); err != nil {
+		return nil, err
 	}
 
-	if a.apiKeyPattern.MatchString(content) {
-		return &domain.Analysis{
-			Type:        domain.ContentTypeText,
-			IsSensitive: true,
-			Reason:      "contains API key",
-		}
+	return a, nil
+}
+
+// ReloadRules re-reads the rules file. The current rules stay in effect if
+// the file cannot be loaded.
+func (a *SensitiveAnalyzer) ReloadRules() error {
+	rules, err := LoadRules(a.rulesFile, a.defaultAction)
+	if err != nil {
+		return err
 	}
 
-	contenType := a.detectType(content)
+	compiled, err := compileRules(rules)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.rules = compiled
+	a.mu.Unlock()
+
+	return nil
+}
 
-	return &domain.Analysis{
-		Type:        contenType,
-		IsSensitive: false,
-		Reason:      "",
+// Rules lists the pattern rules followed by the enabled detectors.
+func (a *SensitiveAnalyzer) Rules() []domain.Rule {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	rules := make([]domain.Rule, 0, len(a.rules)+len(a.detectors))
+	for _, rule := range a.rules {
+		rules = append(rules, rule.Rule)
+	}
+	for _, d := range a.detectors {
+		rules = append(rules, d.Rule())
 	}
+	return rules
+}
+
+func (a *SensitiveAnalyzer) Name() string {
+	return "sensitive"
 }
 
-func (a *SimpleAnalyzer) detectType(content string) domain.ContentType {
-	if strings.HasPrefix(content, "http://") || strings.HasPrefix(content, "https://") {
-		return domain.ContentTypeURL
+func (a *SensitiveAnalyzer) Analyze(ctx context.Context, entry *domain.ClipboardEntry) *domain.Analysis {
+	content := entry.Content
+
+	a.mu.RLock()
+	rules := a.rules
+	a.mu.RUnlock()
+
+	analysis := &domain.Analysis{
+		Matches: matchRules(ctx, rules, a.detectors, content),
+	}
+	if ctx.Err() != nil {
+		// The pipeline has given up on this stage; the result is unused.
+		return analysis
 	}
 
-	if strings.HasPrefix(content, "/") || strings.Contains(content, ":\\") {
-		return domain.ContentTypeFilePath
+	tagged := make(map[string]bool)
+	for i := range analysis.Matches {
+		match := &analysis.Matches[i]
+		match.Analyzer = a.Name()
+
+		if tag := matchTag(*match); tag != "" && !tagged[tag] {
+			tagged[tag] = true
+			analysis.Tags = append(analysis.Tags, tag)
+			analysis.Findings = append(analysis.Findings, domain.Finding{
+				Analyzer: a.Name(),
+				Kind:     domain.FindingTag,
+				Value:    tag,
+				Score:    1,
+			})
+		}
 	}
 
-	codeIndicators := []string{"func ", "def ", "class ", "import ", "const ", "let ", "var "}
+	// The most severe blocking match names the reason, then the most
+	// confident; on a tie the rule listed first wins.
+	for _, match := range analysis.Matches {
+		if match.Action != domain.ActionBlock {
+			continue
+		}
+		if !analysis.IsSensitive || outranks(match, analysis) {
+			analysis.IsSensitive = true
+			analysis.Reason = match.Rule
+			analysis.Severity = match.Severity
+			analysis.Confidence = match.Confidence
+		}
+	}
 
-	for _, indicator := range codeIndicators {
-		if strings.Contains(content, indicator) {
-			return domain.ContentTypeCode
+	// Short-TTL matches only shorten the entry's lifetime; they name the
+	// reason when nothing blocked it, ranked the same way.
+	for _, match := range analysis.Matches {
+		if match.Action != domain.ActionShortTTL || a.shortTTL <= 0 {
+			continue
 		}
+		analysis.TTL = a.shortTTL
+		if !analysis.IsSensitive && (analysis.Reason == "" || outranks(match, analysis)) {
+			analysis.Reason = match.Rule
+			analysis.Severity = match.Severity
+			analysis.Confidence = match.Confidence
+		}
+	}
+
+	return analysis
+}
+
+// matchTag labels what kind of data a match found: "pii" for the PII
+// categories and "secret" for everything else. Allowed matches are not
+// tagged.
+func matchTag(match domain.Match) string {
+	if match.Action == domain.ActionAllow {
+		return ""
+	}
+	switch match.Rule {
+	case piiEmail, piiPhone, piiIBAN, piiNationalID, piiAddress:
+		return "pii"
 	}
+	return "secret"
+}
 
-	return domain.ContentTypeText
+func outranks(match domain.Match, analysis *domain.Analysis) bool {
+	if match.Severity.Rank() != analysis.Severity.Rank() {
+		return match.Severity.Rank() > analysis.Severity.Rank()
+	}
+	return match.Confidence.Rank() > analysis.Confidence.Rank()
 }
@@ -0,0 +1,432 @@
+package analyzer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// minTypeScore is the score a classifier needs to beat plain text.
+const minTypeScore = 0.5
+
+// typeScorer rates how well content fits one content type, from 0 (not at
+// all) to 1 (certain). Scorers see trimmed, non-empty content.
+type typeScorer struct {
+	contentType domain.ContentType
+	score       func(content string) float64
+}
+
+// typeScorers are listed from most to least specific; on equal scores the
+// earlier one wins.
+var typeScorers = []typeScorer{
+	{domain.ContentTypeUUID, scoreUUID},
+	{domain.ContentTypeIP, scoreIP},
+	{domain.ContentTypeEmail, scoreEmail},
+	{domain.ContentTypeURL, scoreURL},
+	{domain.ContentTypeColor, scoreColor},
+	{domain.ContentTypeDate, scoreDate},
+	{domain.ContentTypeNumber, scoreNumber},
+	{domain.ContentTypeJSON, scoreJSON},
+	{domain.ContentTypeXML, scoreXML},
+	{domain.ContentTypeFilePath, scoreFilePath},
+	{domain.ContentTypeSQL, scoreSQL},
+	{domain.ContentTypeShell, scoreShell},
+	{domain.ContentTypeYAML, scoreYAML},
+	{domain.ContentTypeMarkdown, scoreMarkdown},
+	{domain.ContentTypeCode, scoreCode},
+	{domain.ContentTypeBase64, scoreBase64},
+}
+
+// classify returns the best-scoring content type and its score, falling
+// back to text when no scorer is confident enough.
+func classify(content string) (domain.ContentType, float64) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return domain.ContentTypeText, 0
+	}
+
+	best, bestScore := domain.ContentTypeText, 0.0
+	for _, scorer := range typeScorers {
+		if score := scorer.score(content); score > bestScore {
+			best, bestScore = scorer.contentType, score
+		}
+	}
+
+	if bestScore < minTypeScore {
+		return domain.ContentTypeText, bestScore
+	}
+	return best, bestScore
+}
+
+func isSingleToken(content string) bool {
+	return !strings.ContainsAny(content, " \t\r\n")
+}
+
+var uuidPattern = regexp.MustCompile(`^(?i)(urn:uuid:)?\{?[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\}?$`)
+
+func scoreUUID(content string) float64 {
+	if uuidPattern.MatchString(content) {
+		return 1
+	}
+	return 0
+}
+
+func scoreIP(content string) float64 {
+	if !isSingleToken(content) {
+		return 0
+	}
+	if net.ParseIP(content) != nil {
+		return 1
+	}
+	if _, _, err := net.ParseCIDR(content); err == nil {
+		return 1
+	}
+	return 0
+}
+
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}$`)
+
+func scoreEmail(content string) float64 {
+	address := strings.TrimPrefix(content, "mailto:")
+	if !emailPattern.MatchString(address) {
+		return 0
+	}
+	if _, err := mail.ParseAddress(address); err != nil {
+		return 0
+	}
+	return 0.95
+}
+
+func scoreURL(content string) float64 {
+	if !isSingleToken(content) {
+		return 0
+	}
+
+	u, err := url.Parse(content)
+	if err != nil {
+		return 0
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		if u.Host != "" {
+			return 0.95
+		}
+	case "ftp", "ftps", "ws", "wss", "ssh", "git", "file":
+		return 0.9
+	}
+	return 0
+}
+
+var (
+	hexColorPattern  = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+	funcColorPattern = regexp.MustCompile(`^(?i)(rgb|hsl)a?\(\s*[\d.]+%?\s*(,\s*|\s+)[\d.]+%?\s*(,\s*|\s+)[\d.]+%?\s*([,/]\s*[\d.]+%?\s*)?\)$`)
+)
+
+func scoreColor(content string) float64 {
+	if hexColorPattern.MatchString(content) || funcColorPattern.MatchString(content) {
+		return 0.95
+	}
+	return 0
+}
+
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC1123,
+	time.RFC1123Z,
+	time.RFC822,
+	time.RFC850,
+	time.ANSIC,
+	time.UnixDate,
+	"2006-01-02",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006/01/02",
+	"02/01/2006",
+	"01/02/2006",
+	"02.01.2006",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+	"15:04:05",
+}
+
+func scoreDate(content string) float64 {
+	if len(content) > 64 {
+		return 0
+	}
+	for _, layout := range dateLayouts {
+		if _, err := time.Parse(layout, content); err == nil {
+			return 0.95
+		}
+	}
+	return 0
+}
+
+func scoreNumber(content string) float64 {
+	if !isSingleToken(content) {
+		return 0
+	}
+
+	number := strings.NewReplacer("_", "", ",", "").Replace(content)
+	if _, err := strconv.ParseFloat(number, 64); err == nil {
+		return 0.9
+	}
+	if _, err := strconv.ParseInt(number, 0, 64); err == nil { // 0x, 0o, 0b
+		return 0.9
+	}
+	return 0
+}
+
+func scoreJSON(content string) float64 {
+	if content[0] != '{' && content[0] != '[' {
+		return 0
+	}
+	if json.Valid([]byte(content)) {
+		return 1
+	}
+	return 0
+}
+
+func scoreXML(content string) float64 {
+	if content[0] != '<' {
+		return 0
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = true
+
+	elements := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			elements++
+		}
+	}
+
+	if elements == 0 {
+		return 0
+	}
+	return 0.9
+}
+
+var windowsPathPattern = regexp.MustCompile(`^[A-Za-z]:\\`)
+
+func scoreFilePath(content string) float64 {
+	if strings.Contains(content, "\n") {
+		return 0
+	}
+
+	switch {
+	case windowsPathPattern.MatchString(content), strings.HasPrefix(content, `\\`):
+		return 0.9
+	case strings.HasPrefix(content, "/"), strings.HasPrefix(content, "~/"),
+		strings.HasPrefix(content, "./"), strings.HasPrefix(content, "../"):
+		if strings.Count(content, " ") > 2 {
+			return 0.4
+		}
+		return 0.9
+	}
+	return 0
+}
+
+var sqlStatementPattern = regexp.MustCompile(`(?is)^(select\s.+\sfrom\s|insert\s+into\s|update\s+\S+\s+set\s|delete\s+from\s|create\s+(unique\s+)?(table|index|view|database|schema)\s|alter\s+table\s|drop\s+(table|index|view|database)\s|with\s+\S+\s+as\s*\(|truncate\s+table\s)`)
+
+func scoreSQL(content string) float64 {
+	if !sqlStatementPattern.MatchString(content) {
+		return 0
+	}
+
+	// Prose such as "Select the file from the menu" needs more evidence.
+	score := 0.4
+	firstWord := strings.Fields(content)[0]
+	if firstWord == strings.ToUpper(firstWord) {
+		score += 0.4
+	}
+	if strings.ContainsAny(content, ";*=(") || strings.Contains(strings.ToUpper(content), " WHERE ") {
+		score += 0.2
+	}
+	return min(score, 1)
+}
+
+var (
+	shellCommands = map[string]bool{
+		"apt": true, "apt-get": true, "brew": true, "cargo": true, "cat": true, "cd": true,
+		"chmod": true, "chown": true, "cp": true, "curl": true, "docker": true, "echo": true,
+		"export": true, "find": true, "git": true, "go": true, "grep": true, "helm": true,
+		"kill": true, "kubectl": true, "ln": true, "ls": true, "make": true, "mkdir": true,
+		"mv": true, "npm": true, "npx": true, "pip": true, "ps": true, "python": true,
+		"python3": true, "rm": true, "rsync": true, "scp": true, "sed": true, "ssh": true,
+		"sudo": true, "systemctl": true, "tail": true, "tar": true, "wget": true, "yarn": true,
+	}
+
+	shellSyntaxPattern = regexp.MustCompile(`(^|\s)(--?[A-Za-z][\w-]*|\||&&|\|\||>>?|2>&1|\$\(|\$\{?\w+)`)
+)
+
+func scoreShell(content string) float64 {
+	if strings.HasPrefix(content, "#!") && strings.Contains(strings.SplitN(content, "\n", 2)[0], "sh") {
+		return 1
+	}
+
+	line := strings.SplitN(content, "\n", 2)[0]
+	prompt := strings.HasPrefix(line, "$ ") || strings.HasPrefix(line, "# ") && shellCommands[firstField(line[2:])]
+	if prompt {
+		line = line[2:]
+	}
+
+	if !shellCommands[firstField(line)] {
+		return 0
+	}
+
+	score := 0.45
+	if prompt {
+		score += 0.3
+	}
+	if shellSyntaxPattern.MatchString(line) {
+		score += 0.35
+	}
+	return min(score, 1)
+}
+
+func firstField(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+var yamlLinePattern = regexp.MustCompile(`^\s*(#.*|-\s.*|-|[\w.\-"']+:(\s.*)?|---|\.\.\.)$`)
+
+func scoreYAML(content string) float64 {
+	lines := strings.Split(content, "\n")
+	if len(lines) < 2 {
+		return 0
+	}
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" && !yamlLinePattern.MatchString(line) && !strings.HasPrefix(line, " ") {
+			return 0
+		}
+	}
+
+	var doc any
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return 0
+	}
+
+	switch v := doc.(type) {
+	case map[string]any:
+		if len(v) > 0 {
+			return 0.85
+		}
+	case []any:
+		if len(v) > 0 {
+			return 0.8
+		}
+	}
+	return 0
+}
+
+var markdownFeatures = []struct {
+	pattern *regexp.Regexp
+	weight  float64
+}{
+	{regexp.MustCompile("(?m)^```"), 0.5},                   // fenced code
+	{regexp.MustCompile(`(?m)^#{1,6} \S`), 0.4},             // heading
+	{regexp.MustCompile(`(?m)^\|?\s*:?-{3,}:?\s*\|`), 0.4},  // table separator
+	{regexp.MustCompile(`\[[^\]\n]+\]\([^)\s]+\)`), 0.3},    // link
+	{regexp.MustCompile(`(?m)^\s*([-*+]|\d+\.) \S`), 0.2},   // list item
+	{regexp.MustCompile(`(?m)^> `), 0.2},                    // blockquote
+	{regexp.MustCompile(`(\*\*|__)[^*_\n]+(\*\*|__)`), 0.2}, // bold
+	{regexp.MustCompile("`[^`\n]+`"), 0.1},                  // inline code
+}
+
+func scoreMarkdown(content string) float64 {
+	score := 0.0
+	for _, feature := range markdownFeatures {
+		if feature.pattern.MatchString(content) {
+			score += feature.weight
+		}
+	}
+	return min(score, 1)
+}
+
+var codeSignals = []*regexp.Regexp{
+	regexp.MustCompile(`^\s*(func|def|class|fn|pub fn|impl|struct|interface|enum|package|import|from \S+ import|#include|using|namespace|public|private|protected|static)\b`),
+	regexp.MustCompile(`^\s*(const|let|var|val|auto)\s+\w+\s*(:[^=]+)?=`),
+	regexp.MustCompile(`[{};]\s*$`),
+	regexp.MustCompile(`^\s*(if|for|while|switch|match|else|elif|try|catch|return)\b.*([({:]|\S)\s*$`),
+	regexp.MustCompile(`:=|=>|->|::|===|!==|&&|\|\|`),
+	regexp.MustCompile(`\b\w+(\.\w+)*\([^()]*\)\s*[;{:]?\s*$`),
+	regexp.MustCompile(`^\s*(//|/\*|\*/)`),
+}
+
+// scoreCode rates how many lines look like source code. A single signal is
+// not enough, so English prose with "let me" or "import" stays text.
+func scoreCode(content string) float64 {
+	lines, codeLines, signals := 0, 0, 0
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines++
+
+		hits := 0
+		for _, signal := range codeSignals {
+			if signal.MatchString(line) {
+				hits++
+			}
+		}
+		if hits > 0 {
+			codeLines++
+			signals += hits
+		}
+	}
+
+	if lines == 0 || signals < 2 {
+		return 0
+	}
+	return 0.4 + 0.6*float64(codeLines)/float64(lines)
+}
+
+func scoreBase64(content string) float64 {
+	if !isSingleToken(content) || len(content) < 16 || hexPattern.MatchString(content) {
+		return 0
+	}
+
+	data := []byte(content)
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		decoded := make([]byte, encoding.DecodedLen(len(data)))
+		if _, err := encoding.Decode(decoded, data); err == nil {
+			// Long single words decode too; real blobs mix character classes.
+			if charClasses(content) >= 3 || bytes.HasSuffix(data, []byte("=")) {
+				return 0.7
+			}
+			return 0
+		}
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    domain.ContentType
+	}{
+		{name: "empty", content: "   ", want: domain.ContentTypeText},
+		{name: "prose", content: "hello world", want: domain.ContentTypeText},
+		{name: "prose with let", content: "let me know when you are free", want: domain.ContentTypeText},
+		{name: "prose with import", content: "The import of goods rose by 3% last year.", want: domain.ContentTypeText},
+		{name: "prose with select", content: "Select the file from the menu", want: domain.ContentTypeText},
+		{name: "prose with path-like word", content: "and/or whatever", want: domain.ContentTypeText},
+
+		{name: "url", content: "https://example.com/path?q=1", want: domain.ContentTypeURL},
+		{name: "url with surrounding space", content: "  https://example.com\n", want: domain.ContentTypeURL},
+		{name: "email", content: "jane.doe@example.co.uk", want: domain.ContentTypeEmail},
+		{name: "mailto", content: "mailto:jane@example.com", want: domain.ContentTypeEmail},
+		{name: "ipv4", content: "192.168.1.10", want: domain.ContentTypeIP},
+		{name: "ipv6", content: "2001:db8::ff00:42:8329", want: domain.ContentTypeIP},
+		{name: "cidr", content: "10.0.0.0/8", want: domain.ContentTypeIP},
+		{name: "uuid", content: "550e8400-e29b-41d4-a716-446655440000", want: domain.ContentTypeUUID},
+		{name: "hex color", content: "#ff8800", want: domain.ContentTypeColor},
+		{name: "short hex color", content: "#fff", want: domain.ContentTypeColor},
+		{name: "rgb color", content: "rgb(255, 136, 0)", want: domain.ContentTypeColor},
+		{name: "hsla color", content: "hsla(120, 50%, 50%, 0.3)", want: domain.ContentTypeColor},
+		{name: "integer", content: "42", want: domain.ContentTypeNumber},
+		{name: "grouped number", content: "1,234,567.89", want: domain.ContentTypeNumber},
+		{name: "hex number", content: "0x1F", want: domain.ContentTypeNumber},
+		{name: "iso date", content: "2024-03-15", want: domain.ContentTypeDate},
+		{name: "rfc3339", content: "2024-03-15T10:30:00Z", want: domain.ContentTypeDate},
+		{name: "long date", content: "March 15, 2024", want: domain.ContentTypeDate},
+
+		{name: "unix path", content: "/usr/local/bin/clipd", want: domain.ContentTypeFilePath},
+		{name: "home path", content: "~/.config/clipd/rules.json", want: domain.ContentTypeFilePath},
+		{name: "windows path", content: `C:\Users\jane\file.txt`, want: domain.ContentTypeFilePath},
+
+		{name: "json object", content: `{"name": "clipd", "port": 8080}`, want: domain.ContentTypeJSON},
+		{name: "json array", content: `[1, 2, 3]`, want: domain.ContentTypeJSON},
+		{name: "broken json", content: `{"name": "clipd",`, want: domain.ContentTypeText},
+		{name: "xml", content: `<?xml version="1.0"?><note><to>Jane</to></note>`, want: domain.ContentTypeXML},
+		{name: "html fragment", content: "<div class=\"a\">\n  <p>hi</p>\n</div>", want: domain.ContentTypeXML},
+		{name: "unbalanced xml", content: "<note><to>Jane</note>", want: domain.ContentTypeText},
+		{name: "yaml", content: "name: clipd\nport: 8080\ntags:\n  - a\n  - b", want: domain.ContentTypeYAML},
+		{name: "yaml list", content: "- one\n- two\n- three", want: domain.ContentTypeYAML},
+		{name: "single key value", content: "Note: call me", want: domain.ContentTypeText},
+
+		{name: "sql select", content: "SELECT id, name FROM users WHERE id = 1;", want: domain.ContentTypeSQL},
+		{name: "sql lowercase", content: "select * from users", want: domain.ContentTypeSQL},
+		{name: "sql insert", content: "INSERT INTO users (name) VALUES ('jane')", want: domain.ContentTypeSQL},
+		{name: "shebang", content: "#!/bin/bash\necho hi", want: domain.ContentTypeShell},
+		{name: "prompt", content: "$ ls -la", want: domain.ContentTypeShell},
+		{name: "command with flags", content: "docker run --rm -it alpine", want: domain.ContentTypeShell},
+		{name: "pipeline", content: "cat access.log | grep 404", want: domain.ContentTypeShell},
+		{name: "base64", content: "SGVsbG8sIFdvcmxkISBUaGlzIGlzIGJhc2U2NA==", want: domain.ContentTypeBase64},
+		{name: "long word", content: "supercalifragilistic", want: domain.ContentTypeText},
+		{name: "markdown", content: "# Title\n\nSome **bold** text and a [link](https://example.com).", want: domain.ContentTypeMarkdown},
+		{name: "markdown fence", content: "Run this:\n\n```\nmake build\n```", want: domain.ContentTypeMarkdown},
+
+		{name: "go code", content: "func main() {\n\tfmt.Println(\"hi\")\n}", want: domain.ContentTypeCode},
+		{name: "python code", content: "def add(a, b):\n    return a + b", want: domain.ContentTypeCode},
+		{name: "javascript code", content: "const x = 1;\nlet y = x + 2;", want: domain.ContentTypeCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, score := classify(tt.content)
+			if got != tt.want {
+				t.Errorf("classify(%q) = %s (score %.2f), want %s", tt.content, got, score, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyScores(t *testing.T) {
+	if _, score := classify(`{"a": 1}`); score != 1 {
+		t.Errorf("expected certain score for valid JSON, got %.2f", score)
+	}
+	if got, score := classify("just some words"); got != domain.ContentTypeText || score >= minTypeScore {
+		t.Errorf("expected text below threshold, got %s with %.2f", got, score)
+	}
+}
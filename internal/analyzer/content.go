@@ -0,0 +1,45 @@
+package analyzer
+
+import (
+	"context"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// ContentAnalyzer classifies what an entry is: its content type and, for
+// code, its programming language.
+type ContentAnalyzer struct{}
+
+func NewContentAnalyzer() *ContentAnalyzer {
+	return &ContentAnalyzer{}
+}
+
+func (a *ContentAnalyzer) Name() string {
+	return "content"
+}
+
+func (a *ContentAnalyzer) Analyze(ctx context.Context, entry *domain.ClipboardEntry) *domain.Analysis {
+	contentType, score := classify(entry.Content)
+	if ctx.Err() != nil {
+		return &domain.Analysis{}
+	}
+
+	analysis := &domain.Analysis{
+		Type: contentType,
+		Findings: []domain.Finding{
+			{Analyzer: a.Name(), Kind: domain.FindingType, Value: string(contentType), Score: score},
+		},
+	}
+
+	if lang, score := languageFor(contentType, entry.Content); lang != "" {
+		analysis.Language = lang
+		analysis.Findings = append(analysis.Findings, domain.Finding{
+			Analyzer: a.Name(),
+			Kind:     domain.FindingLanguage,
+			Value:    lang,
+			Score:    score,
+		})
+	}
+
+	return analysis
+}
@@ -0,0 +1,220 @@
+package analyzer
+
+import (
+	"encoding/base64"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+const entropyRule = "high_entropy"
+
+var (
+	// Runs of base64/base64url characters, with optional padding. '=' is
+	// only allowed at the end so that "key=value" splits into two tokens.
+	entropyTokenPattern = regexp.MustCompile(`[A-Za-z0-9+/_-]+={0,2}`)
+
+	hexPattern = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+	// A path segment such as "usr", "static" or "app", which random base64
+	// rarely produces between two slashes.
+	pathWordPattern = regexp.MustCompile(`^[a-z]{3,}$`)
+
+	// Random-looking strings that are identifiers rather than secrets.
+	entropyAllowlist = []*regexp.Regexp{
+		regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`), // UUID
+		// Digests: MD5, SHA-1, SHA-256 and SHA-512 in either case. The
+		// "sha256:" prefix of a docker or OCI digest splits off at the ':'.
+		regexp.MustCompile(`^(?:[0-9a-f]{32}|[0-9a-f]{40}|[0-9a-f]{64}|[0-9a-f]{128})$`),
+		regexp.MustCompile(`^(?:[0-9A-F]{32}|[0-9A-F]{40}|[0-9A-F]{64}|[0-9A-F]{128})$`),
+		regexp.MustCompile(`^sha(256|384|512)-[A-Za-z0-9+/]+={0,2}$`), // subresource integrity
+	}
+)
+
+// entropyDetector flags unlabelled secrets: long base64- or hex-looking
+// tokens whose Shannon entropy is too high to be words or identifiers.
+type entropyDetector struct {
+	action          domain.RuleAction
+	minLength       int
+	minClasses      int
+	base64Threshold float64
+	hexThreshold    float64
+}
+
+func newEntropyDetector(cfg config.AnalyzerConfig, action domain.RuleAction) *entropyDetector {
+	return &entropyDetector{
+		action:          action,
+		minLength:       cfg.EntropyMinLength,
+		minClasses:      cfg.EntropyMinClasses,
+		base64Threshold: cfg.EntropyBase64Threshold,
+		hexThreshold:    cfg.EntropyHexThreshold,
+	}
+}
+
+func (d *entropyDetector) Rule() domain.Rule {
+	return domain.Rule{
+		Name:     entropyRule,
+		Severity: domain.SeverityMedium,
+		Action:   d.action,
+		Builtin:  true,
+		Detector: true,
+	}
+}
+
+func (d *entropyDetector) Detect(content string) []domain.Match {
+	var matches []domain.Match
+
+	for _, loc := range entropyTokenPattern.FindAllStringIndex(content, -1) {
+		for _, span := range d.tokens(content[loc[0]:loc[1]]) {
+			start, end := loc[0]+span[0], loc[0]+span[1]
+			if d.isSecret(content[start:end]) {
+				matches = append(matches, domain.Match{
+					Rule:       entropyRule,
+					Severity:   domain.SeverityMedium,
+					Confidence: domain.ConfidenceLow,
+					Action:     d.action,
+					Start:      start,
+					End:        end,
+				})
+			}
+		}
+	}
+
+	return matches
+}
+
+// tokens splits a run matched by entropyTokenPattern into the spans scored
+// for entropy. Runs with '/' are file or URL paths unless they decode as
+// padded base64 without any plain word between the slashes; the segments
+// of a path are scored on their own, and hex segments in them are taken
+// for content hashes rather than secrets.
+func (d *entropyDetector) tokens(run string) [][2]int {
+	if !strings.Contains(run, "/") || isAllowlisted(run) {
+		return [][2]int{{0, len(run)}}
+	}
+
+	segments := strings.Split(run, "/")
+	if isPaddedBase64(run) && !slices.ContainsFunc(segments, pathWordPattern.MatchString) {
+		return [][2]int{{0, len(run)}}
+	}
+
+	var spans [][2]int
+	start := 0
+	for _, segment := range segments {
+		end := start + len(segment)
+		if segment != "" && !hexPattern.MatchString(strings.TrimRight(segment, "=")) {
+			spans = append(spans, [2]int{start, end})
+		}
+		start = end + 1
+	}
+	return spans
+}
+
+// isPaddedBase64 reports whether s is standard base64, padded to a whole
+// number of quads.
+func isPaddedBase64(s string) bool {
+	_, err := base64.StdEncoding.DecodeString(s)
+	return err == nil
+}
+
+func isAllowlisted(token string) bool {
+	for _, allow := range entropyAllowlist {
+		if allow.MatchString(token) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *entropyDetector) isSecret(token string) bool {
+	if len(token) < d.minLength {
+		return false
+	}
+
+	if isAllowlisted(token) {
+		return false
+	}
+
+	body := strings.TrimRight(token, "=")
+
+	if hexPattern.MatchString(body) {
+		// Hex needs both digits and letters, which rules out long numbers.
+		if strings.IndexFunc(body, isDigit) < 0 || strings.IndexFunc(body, isLetter) < 0 {
+			return false
+		}
+		return shannonEntropy(body) >= threshold(d.hexThreshold, len(body))
+	}
+
+	if charClasses(body) < d.minClasses {
+		return false
+	}
+	return shannonEntropy(body) >= threshold(d.base64Threshold, len(body))
+}
+
+// threshold caps the configured threshold for short tokens, which cannot
+// reach it: n characters carry at most log2(n) bits each.
+func threshold(configured float64, n int) float64 {
+	return min(configured, 0.9*math.Log2(float64(n)))
+}
+
+// shannonEntropy returns the entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+
+	entropy := 0.0
+	n := float64(len(s))
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / n
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
+
+// charClasses counts which of upper case, lower case, digits and symbols
+// appear in s.
+func charClasses(s string) int {
+	var upper, lower, digit, symbol bool
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case isDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{upper, lower, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+	return classes
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
@@ -0,0 +1,181 @@
+package analyzer
+
+import (
+	"regexp"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// Languages reported by detectLanguage.
+const (
+	langGo         = "go"
+	langPython     = "python"
+	langJavaScript = "javascript"
+	langTypeScript = "typescript"
+	langRust       = "rust"
+	langJava       = "java"
+	langSQL        = "sql"
+	langBash       = "bash"
+	langYAML       = "yaml"
+	langHTML       = "html"
+	langCSS        = "css"
+)
+
+// minLanguageScore is the evidence a language needs before it is reported.
+const minLanguageScore = 3
+
+// maxSignalHits caps how often one signal counts, so a long file full of
+// semicolons does not outweigh a single distinctive keyword.
+const maxSignalHits = 3
+
+type languageSignal struct {
+	pattern *regexp.Regexp
+	weight  float64
+}
+
+type languageProfile struct {
+	name    string
+	signals []languageSignal
+}
+
+func signal(pattern string, weight float64) languageSignal {
+	return languageSignal{pattern: regexp.MustCompile("(?m)" + pattern), weight: weight}
+}
+
+var javaScriptSignals = []languageSignal{
+	signal(`\b(const|let|var)\s+\w+\s*=`, 1.5),
+	signal(`=>`, 1),
+	signal(`\bfunction\s*\w*\s*\(`, 2),
+	signal(`\bconsole\.\w+\(`, 2.5),
+	signal(`\brequire\(['"]`, 2),
+	signal(`\b(document|window)\.\w+`, 2),
+	signal(`===|!==`, 1.5),
+	signal(`^\s*import\s.+\sfrom\s+['"]`, 2),
+	signal(`^\s*export\s+(default\s+)?(const|function|class|async)\b`, 2),
+	signal(`\b(async|await)\b`, 0.5),
+}
+
+// languageProfiles are listed so that on equal scores the more general
+// language wins: JavaScript before TypeScript, which only adds type syntax.
+var languageProfiles = []languageProfile{
+	{langGo, []languageSignal{
+		signal(`^package \w+\s*$`, 3),
+		signal(`\bfunc\s+(\(\w+\s+\*?\w+\)\s*)?\w+\(`, 3),
+		signal(`\bfunc\s*\(`, 1),
+		signal(`:=`, 1.5),
+		signal(`\bfmt\.\w+\(`, 2),
+		signal(`\berr != nil\b`, 3),
+		signal(`^import \($|^import "`, 2),
+		signal(`\bgo func\b|\bdefer\s|\bchan\s|<-`, 1.5),
+		signal(`\btype\s+\w+\s+(struct|interface)\s*\{`, 3),
+	}},
+	{langPython, []languageSignal{
+		signal(`^\s*(async\s+)?def\s+\w+\(.*\)(\s*->\s*[\w\[\], .]+)?:\s*$`, 3),
+		signal(`^\s*class\s+\w+(\(.*\))?:\s*$`, 3),
+		signal(`^\s*(from\s+[\w.]+\s+)?import\s+[\w.]+(\s+as\s+\w+)?\s*$`, 1.5),
+		signal(`\bself\.\w+`, 2),
+		signal(`\b(elif|None|True|False)\b`, 1),
+		signal(`^\s*(if|for|while|with|try|except|else)\b.*:\s*$`, 1),
+		signal(`__\w+__`, 1.5),
+		signal(`\bprint\(`, 0.5),
+	}},
+	{langJavaScript, javaScriptSignals},
+	{langTypeScript, append([]languageSignal{
+		signal(`\w\s*:\s*(string|number|boolean|any|void|unknown|never)\b`, 3),
+		signal(`^\s*(export\s+)?(interface|type)\s+\w+(<[^>]*>)?\s*(=|\{)`, 2.5),
+		signal(`\b(readonly|private|public)\s+\w+\s*:`, 1.5),
+	}, javaScriptSignals...)},
+	{langRust, []languageSignal{
+		signal(`\bfn\s+\w+\s*(<[^>]*>)?\(`, 3),
+		signal(`\blet\s+mut\b`, 3),
+		signal(`\bimpl\b`, 2),
+		signal(`\b\w+!\(`, 2.5),
+		signal(`^\s*use\s+\w+(::[\w{}*, ]+)+;`, 2.5),
+		signal(`\bpub\s+(fn|struct|enum|mod|trait)\b`, 2),
+		signal(`&str\b|&mut\b|\bOption<|\bResult<|\bVec<`, 2),
+		signal(`::`, 0.5),
+	}},
+	{langJava, []languageSignal{
+		signal(`\bpublic\s+(static\s+)?(final\s+)?(class|void|interface)\b`, 3),
+		signal(`\bSystem\.(out|err)\.print`, 3),
+		signal(`^\s*import\s+java(x)?\.`, 3),
+		signal(`^\s*package\s+[\w.]+;`, 3),
+		signal(`@Override\b`, 2.5),
+		signal(`\b(private|protected)\s+(static\s+)?(final\s+)?\w+(<[^>]*>)?\s+\w+\s*[;=(]`, 2),
+		signal(`\bString\[\]`, 2),
+		signal(`\bnew\s+\w+(<[^>]*>)?\(`, 1),
+	}},
+	{langSQL, []languageSignal{
+		signal(`(?i)^\s*(select\s.+\sfrom|insert\s+into|update\s+\w+\s+set|delete\s+from|create\s+(table|index|view)|alter\s+table|drop\s+table)\b`, 3),
+		signal(`\b(SELECT|FROM|WHERE|JOIN|GROUP BY|ORDER BY|VALUES|LIMIT)\b`, 1.5),
+	}},
+	{langBash, []languageSignal{
+		signal(`\A#!.*\b(ba|z|k)?sh\b`, 5),
+		signal(`^\s*(if\s+\[\[?|fi$|then$|do$|done$|esac$)`, 2.5),
+		signal(`^\s*export\s+\w+=`, 2),
+		signal(`\|\s*(grep|awk|sed|xargs|sort|uniq|head|tail)\b`, 2),
+		signal(`\$\(|\$\{\w+`, 1.5),
+		signal(`^\s*(sudo|apt|cd|ls|mkdir|rm|cp|mv|chmod|curl|git|docker|echo)\s`, 1.5),
+		signal(`\$\w+`, 0.5),
+	}},
+	{langYAML, []languageSignal{
+		signal(`^---\s*$`, 2),
+		signal(`^\s*- [\w.-]+:\s`, 2),
+		signal(`^[\w.-]+:\s*$`, 1),
+		signal(`^\s*[\w.-]+:\s+\S`, 0.5),
+	}},
+	{langHTML, []languageSignal{
+		signal(`(?i)<!DOCTYPE\s+html`, 5),
+		signal(`(?i)</?(html|head|body|div|span|p|a|ul|ol|li|script|style|table|tr|td|form|input|button|h[1-6]|section|nav|img)\b[^>]*>`, 2),
+		signal(`\b(class|id|href|src)="`, 1),
+	}},
+	{langCSS, []languageSignal{
+		signal(`^\s*[.#]?[\w-]+(\s*[,>+~]?\s*[.#:]?[\w-]+)*\s*\{\s*$`, 1),
+		signal(`^\s*[a-z-]+\s*:\s*[^;{}]+;\s*$`, 2),
+		signal(`@(media|import|keyframes|font-face)\b`, 3),
+		signal(`\b\d+(\.\d+)?(px|em|rem|vh|vw)\b`, 1.5),
+		signal(`:(hover|focus|active)\b|::(before|after)\b`, 2),
+	}},
+}
+
+// detectLanguage identifies the programming language of code by weighing
+// keyword and token signals, and scores the evidence from 0 to 1. It
+// returns "" when no language has enough evidence.
+func detectLanguage(content string) (string, float64) {
+	best, bestScore := "", 0.0
+	for _, profile := range languageProfiles {
+		score := 0.0
+		for _, s := range profile.signals {
+			hits := len(s.pattern.FindAllStringIndex(content, maxSignalHits))
+			score += s.weight * float64(hits)
+		}
+		if score > bestScore {
+			best, bestScore = profile.name, score
+		}
+	}
+
+	if bestScore < minLanguageScore {
+		return "", 0
+	}
+	return best, min(bestScore/(4*minLanguageScore), 1)
+}
+
+// languageFor names the language of content of the given type. Types that
+// already pin the language map to it directly.
+func languageFor(contentType domain.ContentType, content string) (string, float64) {
+	switch contentType {
+	case domain.ContentTypeSQL:
+		return langSQL, 1
+	case domain.ContentTypeShell:
+		return langBash, 1
+	case domain.ContentTypeYAML:
+		return langYAML, 1
+	case domain.ContentTypeCode:
+		return detectLanguage(content)
+	case domain.ContentTypeXML:
+		if lang, score := detectLanguage(content); lang == langHTML {
+			return lang, score
+		}
+	}
+	return "", 0
+}
@@ -0,0 +1,91 @@
+package analyzer
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "go",
+			content: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tmsg := \"hi\"\n\tfmt.Println(msg)\n}",
+			want:    langGo,
+		},
+		{
+			name:    "go error handling",
+			content: "if err != nil {\n\treturn nil, err\n}",
+			want:    langGo,
+		},
+		{
+			name:    "python",
+			content: "class Greeter:\n    def greet(self, name):\n        print(f\"hi {name}\")",
+			want:    langPython,
+		},
+		{
+			name:    "javascript",
+			content: "const add = (a, b) => a + b;\nconsole.log(add(1, 2));",
+			want:    langJavaScript,
+		},
+		{
+			name:    "typescript",
+			content: "interface User {\n  name: string;\n}\nconst greet = (u: User): string => `hi ${u.name}`;",
+			want:    langTypeScript,
+		},
+		{
+			name:    "rust",
+			content: "fn main() {\n    let mut v = Vec::new();\n    v.push(1);\n    println!(\"{:?}\", v);\n}",
+			want:    langRust,
+		},
+		{
+			name:    "java",
+			content: "public class Hello {\n    public static void main(String[] args) {\n        System.out.println(\"hi\");\n    }\n}",
+			want:    langJava,
+		},
+		{
+			name:    "sql",
+			content: "SELECT name, COUNT(*)\nFROM users\nGROUP BY name;",
+			want:    langSQL,
+		},
+		{
+			name:    "bash",
+			content: "#!/bin/bash\nfor f in *.log; do\n  echo \"$f\"\ndone",
+			want:    langBash,
+		},
+		{
+			name:    "yaml",
+			content: "services:\n  web:\n    image: nginx\n    ports:\n      - \"80:80\"",
+			want:    langYAML,
+		},
+		{
+			name:    "html",
+			content: "<!DOCTYPE html>\n<html>\n<body>\n<div class=\"box\"><p>Hi</p></div>\n</body>\n</html>",
+			want:    langHTML,
+		},
+		{
+			name:    "css",
+			content: ".button {\n  color: #fff;\n  padding: 4px 8px;\n}\n.button:hover {\n  color: red;\n}",
+			want:    langCSS,
+		},
+		{
+			name:    "prose",
+			content: "let me know if the import went fine",
+			want:    "",
+		},
+		{
+			name:    "url",
+			content: "https://example.com",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, _ := classify(tt.content)
+			if got, _ := languageFor(contentType, tt.content); got != tt.want {
+				t.Errorf("languageFor(%s, %q) = %q, want %q", contentType, tt.content, got, tt.want)
+			}
+		})
+	}
+}
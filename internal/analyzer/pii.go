@@ -0,0 +1,184 @@
+package analyzer
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// PII categories. Each is reported as the rule name, so an entry blocked
+// for containing a phone number has Reason "phone".
+const (
+	piiEmail      = "email"
+	piiPhone      = "phone"
+	piiIBAN       = "iban"
+	piiNationalID = "national_id"
+	piiAddress    = "address"
+)
+
+var (
+	streetSuffixes = `Street|St|Avenue|Ave|Road|Rd|Boulevard|Blvd|Lane|Ln|Drive|Dr|Court|Ct|Way|Place|Pl|Terrace|Ter|Parkway|Pkwy|Highway|Hwy|Circle|Cir|Square|Sq`
+
+	// The trailing "City, ST 12345" part is optional; when present it
+	// raises the confidence.
+	addressPattern = regexp.MustCompile(`\b(\d{1,6}(?:\s+[A-Z][A-Za-z]*\.?){1,4}\s+(?:` + streetSuffixes + `)\b\.?(?:,?\s+(?:Apt|Suite|Unit|#)\.?\s*[A-Za-z0-9-]+)?(?:,\s*[A-Z][A-Za-z .]+,\s*[A-Z]{2}\s+\d{5}(?:-\d{4})?)?|(?i:\bP\.?\s?O\.?\s+Box\s+\d+))`)
+
+	zipPattern = regexp.MustCompile(`,\s*[A-Z]{2}\s+\d{5}(?:-\d{4})?$`)
+)
+
+func piiDetectors(policy map[string]domain.RuleAction) []detector {
+	detectors := []*formatDetector{
+		{
+			name:     piiEmail,
+			pattern:  regexp.MustCompile(`\b([A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,})\b`),
+			group:    1,
+			severity: domain.SeverityLow,
+			validate: validateEmail,
+		},
+		{
+			name: piiPhone,
+			// E.164 (+44 20 7946 0958, +14155552671) or a North American or
+			// European national number with separators ((415) 555-2671,
+			// 020 7946 0958).
+			pattern:  regexp.MustCompile(`(?:^|[^\w+])(\+[1-9]\d{0,2}[ .-]?(?:\(\d{1,4}\)[ .-]?)?\d{1,4}(?:[ .-]?\d{2,4}){1,4}|\(\d{3}\)\s?\d{3}[ .-]\d{4}|\b\d{3}[.-]\d{3}[.-]\d{4}|\b0\d{2,4} \d{3,4} ?\d{3,4})\b`),
+			group:    1,
+			severity: domain.SeverityMedium,
+			validate: validatePhone,
+		},
+		{
+			name:     piiIBAN,
+			pattern:  regexp.MustCompile(`\b([A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,4})?)\b`),
+			group:    1,
+			severity: domain.SeverityHigh,
+			validate: validateIBAN,
+		},
+		{
+			name:     piiNationalID,
+			pattern:  regexp.MustCompile(`\b(\d{3}-\d{2}-\d{4})\b`),
+			group:    1,
+			severity: domain.SeverityHigh,
+			validate: validateSSN,
+		},
+		{
+			name:     piiAddress,
+			pattern:  addressPattern,
+			group:    1,
+			severity: domain.SeverityMedium,
+			validate: validateAddress,
+		},
+	}
+
+	all := make([]detector, len(detectors))
+	for i, d := range detectors {
+		d.action = policy[d.name]
+		all[i] = d
+	}
+	return all
+}
+
+// parsePIIPolicy checks the configured category actions. Categories left
+// out are redacted.
+func parsePIIPolicy(policy map[string]string) (map[string]domain.RuleAction, error) {
+	actions := map[string]domain.RuleAction{
+		piiEmail:      domain.ActionRedact,
+		piiPhone:      domain.ActionRedact,
+		piiIBAN:       domain.ActionRedact,
+		piiNationalID: domain.ActionRedact,
+		piiAddress:    domain.ActionRedact,
+	}
+
+	categories := make([]string, 0, len(policy))
+	for category := range policy {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	for _, category := range categories {
+		if _, ok := actions[category]; !ok {
+			return nil, fmt.Errorf("unknown PII category %q", category)
+		}
+
+		action := domain.RuleAction(strings.ToLower(policy[category]))
+		switch action {
+		case domain.ActionAllow, domain.ActionRedact, domain.ActionBlock, domain.ActionShortTTL:
+			actions[category] = action
+		default:
+			return nil, fmt.Errorf("PII category %q: unknown action %q (want allow, redact, block or short_ttl)", category, policy[category])
+		}
+	}
+
+	return actions, nil
+}
+
+// validateEmail skips scp-style git remotes such as git@github.com.
+func validateEmail(address string) (domain.Confidence, bool) {
+	if strings.HasPrefix(address, "git@") {
+		return "", false
+	}
+	return domain.ConfidenceHigh, true
+}
+
+// validatePhone requires a plausible number of digits: 8 to 15 for E.164
+// and 10 or 11 for national formats, which are less certain.
+func validatePhone(number string) (domain.Confidence, bool) {
+	digits := 0
+	for i := 0; i < len(number); i++ {
+		if number[i] >= '0' && number[i] <= '9' {
+			digits++
+		}
+	}
+
+	if strings.HasPrefix(number, "+") {
+		return domain.ConfidenceHigh, digits >= 8 && digits <= 15
+	}
+	return domain.ConfidenceMedium, digits >= 10 && digits <= 11
+}
+
+// validateIBAN applies the ISO 13616 mod-97 checksum.
+func validateIBAN(iban string) (domain.Confidence, bool) {
+	iban = strings.ReplaceAll(iban, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return "", false
+	}
+
+	var digits strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			fmt.Fprintf(&digits, "%d", r-'A'+10)
+		default:
+			return "", false
+		}
+	}
+
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	if !ok || new(big.Int).Mod(n, big.NewInt(97)).Int64() != 1 {
+		return "", false
+	}
+	return domain.ConfidenceHigh, true
+}
+
+// validateSSN rejects numbers the SSA never issues: area 000, 666 or 9xx,
+// group 00 and serial 0000.
+func validateSSN(ssn string) (domain.Confidence, bool) {
+	area, group, serial := ssn[0:3], ssn[4:6], ssn[7:11]
+	if area == "000" || area == "666" || area[0] == '9' || group == "00" || serial == "0000" {
+		return "", false
+	}
+	return domain.ConfidenceHigh, true
+}
+
+// validateAddress is more confident when the street is followed by a
+// state and ZIP code.
+func validateAddress(address string) (domain.Confidence, bool) {
+	if zipPattern.MatchString(address) {
+		return domain.ConfidenceHigh, true
+	}
+	return domain.ConfidenceMedium, true
+}
@@ -0,0 +1,319 @@
+package analyzer
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+func blockAllPII() map[string]string {
+	return map[string]string{
+		"email":       "block",
+		"phone":       "block",
+		"iban":        "block",
+		"national_id": "block",
+		"address":     "block",
+	}
+}
+
+func TestPIIDetectors(t *testing.T) {
+	cfg := config.Default().Analyzer
+	cfg.EntropyEnabled = false
+	cfg.PIIPolicy = blockAllPII()
+
+	a, err := NewSensitiveAnalyzer(cfg)
+	if err != nil {
+		t.Fatalf("failed to create analyzer: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		content        string
+		wantReason     string
+		wantConfidence domain.Confidence
+		wantSpan       string
+	}{
+		{
+			name:           "email",
+			content:        "Contact jane.doe@example.co.uk for access",
+			wantReason:     "email",
+			wantConfidence: domain.ConfidenceHigh,
+			wantSpan:       "jane.doe@example.co.uk",
+		},
+		{
+			name:           "e164 phone",
+			content:        "call +14155552671 tomorrow",
+			wantReason:     "phone",
+			wantConfidence: domain.ConfidenceHigh,
+			wantSpan:       "+14155552671",
+		},
+		{
+			name:           "international phone with spaces",
+			content:        "Tel: +44 20 7946 0958",
+			wantReason:     "phone",
+			wantConfidence: domain.ConfidenceHigh,
+			wantSpan:       "+44 20 7946 0958",
+		},
+		{
+			name:           "us national phone",
+			content:        "Office (415) 555-2671",
+			wantReason:     "phone",
+			wantConfidence: domain.ConfidenceMedium,
+			wantSpan:       "(415) 555-2671",
+		},
+		{
+			name:           "uk national phone",
+			content:        "ring 020 7946 0958 after 5",
+			wantReason:     "phone",
+			wantConfidence: domain.ConfidenceMedium,
+			wantSpan:       "020 7946 0958",
+		},
+		{
+			name:           "iban",
+			content:        "IBAN: GB82 WEST 1234 5698 7654 32",
+			wantReason:     "iban",
+			wantConfidence: domain.ConfidenceHigh,
+			wantSpan:       "GB82 WEST 1234 5698 7654 32",
+		},
+		{
+			name:           "compact iban",
+			content:        "DE89370400440532013000",
+			wantReason:     "iban",
+			wantConfidence: domain.ConfidenceHigh,
+			wantSpan:       "DE89370400440532013000",
+		},
+		{
+			name:           "ssn",
+			content:        "SSN 219-09-9999",
+			wantReason:     "national_id",
+			wantConfidence: domain.ConfidenceHigh,
+			wantSpan:       "219-09-9999",
+		},
+		{
+			name:           "street address",
+			content:        "Ship to 1600 Pennsylvania Avenue please",
+			wantReason:     "address",
+			wantConfidence: domain.ConfidenceMedium,
+			wantSpan:       "1600 Pennsylvania Avenue",
+		},
+		{
+			name:           "full address",
+			content:        "742 Evergreen Terrace, Springfield, OR 97475",
+			wantReason:     "address",
+			wantConfidence: domain.ConfidenceHigh,
+			wantSpan:       "742 Evergreen Terrace, Springfield, OR 97475",
+		},
+		{
+			name:           "po box",
+			content:        "PO Box 1234",
+			wantReason:     "address",
+			wantConfidence: domain.ConfidenceMedium,
+			wantSpan:       "PO Box 1234",
+		},
+
+		// Look-alikes
+		{name: "git remote", content: "git@github.com:geodask/clipboard-manager.git"},
+		{name: "iban with bad checksum", content: "GB82 WEST 1234 5698 7654 33"},
+		{name: "ssn with area 000", content: "000-12-3456"},
+		{name: "ssn with area 9xx", content: "912-34-5678"},
+		{name: "date", content: "2024-03-15"},
+		{name: "version", content: "v1.2.3"},
+		{name: "ip address", content: "192.168.100.200"},
+		{name: "short plus number", content: "+123"},
+		{name: "count with street word", content: "3 Way merge"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := a.Analyze(context.Background(), &domain.ClipboardEntry{Content: tt.content})
+
+			if got.Reason != tt.wantReason {
+				t.Fatalf("expected reason %q, got %q (matches %+v)", tt.wantReason, got.Reason, got.Matches)
+			}
+			if tt.wantReason == "" {
+				return
+			}
+			if !got.IsSensitive {
+				t.Errorf("expected blocked PII to be sensitive")
+			}
+			if got.Confidence != tt.wantConfidence {
+				t.Errorf("expected confidence %q, got %q", tt.wantConfidence, got.Confidence)
+			}
+
+			found := false
+			for _, m := range got.Matches {
+				if m.Rule == tt.wantReason && tt.content[m.Start:m.End] == tt.wantSpan {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected %s match %q, got %+v", tt.wantReason, tt.wantSpan, got.Matches)
+			}
+		})
+	}
+}
+
+func TestPIIPolicy(t *testing.T) {
+	cfg := config.Default().Analyzer
+	cfg.EntropyEnabled = false
+	cfg.ShortTTL = 5 * time.Minute
+	cfg.PIIPolicy = map[string]string{
+		"email":       "allow",
+		"phone":       "short_ttl",
+		"national_id": "block",
+		"address":     "redact",
+	}
+
+	a, err := NewSensitiveAnalyzer(cfg)
+	if err != nil {
+		t.Fatalf("failed to create analyzer: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		content       string
+		wantSensitive bool
+		wantReason    string
+		wantTTL       time.Duration
+		wantAction    domain.RuleAction
+	}{
+		{
+			name:       "allowed category",
+			content:    "jane@example.com",
+			wantAction: domain.ActionAllow,
+		},
+		{
+			name:       "short ttl category",
+			content:    "call +14155552671",
+			wantReason: "phone",
+			wantTTL:    5 * time.Minute,
+			wantAction: domain.ActionShortTTL,
+		},
+		{
+			name:          "blocked category",
+			content:       "SSN 219-09-9999",
+			wantSensitive: true,
+			wantReason:    "national_id",
+			wantAction:    domain.ActionBlock,
+		},
+		{
+			name:       "redacted category",
+			content:    "1600 Pennsylvania Avenue",
+			wantAction: domain.ActionRedact,
+		},
+		{
+			name:          "block wins over short ttl",
+			content:       "+14155552671 / 219-09-9999",
+			wantSensitive: true,
+			wantReason:    "national_id",
+			wantTTL:       5 * time.Minute,
+			wantAction:    domain.ActionBlock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := a.Analyze(context.Background(), &domain.ClipboardEntry{Content: tt.content})
+
+			if got.IsSensitive != tt.wantSensitive {
+				t.Errorf("expected IsSensitive=%v, got %v", tt.wantSensitive, got.IsSensitive)
+			}
+			if got.Reason != tt.wantReason {
+				t.Errorf("expected reason %q, got %q", tt.wantReason, got.Reason)
+			}
+			if got.TTL != tt.wantTTL {
+				t.Errorf("expected TTL %s, got %s", tt.wantTTL, got.TTL)
+			}
+
+			found := false
+			for _, m := range got.Matches {
+				if m.Action == tt.wantAction {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected a %s match, got %+v", tt.wantAction, got.Matches)
+			}
+		})
+	}
+}
+
+func TestPIIDefaults(t *testing.T) {
+	cfg := config.Default().Analyzer
+	cfg.EntropyEnabled = false
+
+	a, err := NewSensitiveAnalyzer(cfg)
+	if err != nil {
+		t.Fatalf("failed to create analyzer: %v", err)
+	}
+
+	tests := []struct {
+		content       string
+		wantSensitive bool
+		wantReason    string
+		wantAction    domain.RuleAction
+	}{
+		{content: "jane@example.com", wantAction: domain.ActionRedact},
+		{content: "call 415-555-2671", wantAction: domain.ActionRedact},
+		{content: "1600 Pennsylvania Avenue", wantAction: domain.ActionRedact},
+		{content: "GB82WEST12345698765432", wantSensitive: true, wantReason: "iban", wantAction: domain.ActionBlock},
+		{content: "SSN 219-09-9999", wantSensitive: true, wantReason: "national_id", wantAction: domain.ActionBlock},
+	}
+
+	for _, tt := range tests {
+		got := a.Analyze(context.Background(), &domain.ClipboardEntry{Content: tt.content})
+
+		if got.IsSensitive != tt.wantSensitive || got.Reason != tt.wantReason {
+			t.Errorf("%q: expected sensitive=%v reason=%q, got sensitive=%v reason=%q", tt.content, tt.wantSensitive, tt.wantReason, got.IsSensitive, got.Reason)
+		}
+		if !slices.ContainsFunc(got.Matches, func(m domain.Match) bool { return m.Action == tt.wantAction }) {
+			t.Errorf("%q: expected a %s match, got %+v", tt.content, tt.wantAction, got.Matches)
+		}
+	}
+}
+
+func TestParsePIIPolicyErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy map[string]string
+	}{
+		{name: "unknown category", policy: map[string]string{"passport": "block"}},
+		{name: "unknown action", policy: map[string]string{"email": "shred"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default().Analyzer
+			cfg.PIIPolicy = tt.policy
+
+			if _, err := NewSensitiveAnalyzer(cfg); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestValidateIBAN(t *testing.T) {
+	tests := []struct {
+		iban string
+		want bool
+	}{
+		{"GB82WEST12345698765432", true},
+		{"DE89370400440532013000", true},
+		{"FR1420041010050500013M02606", true},
+		{"NL91ABNA0417164300", true},
+		{"GB82WEST12345698765433", false},
+		{"GB82", false},
+		{"XX00000000000000", false},
+	}
+
+	for _, tt := range tests {
+		if _, got := validateIBAN(tt.iban); got != tt.want {
+			t.Errorf("validateIBAN(%q) = %v, want %v", tt.iban, got, tt.want)
+		}
+	}
+}
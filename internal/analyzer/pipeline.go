@@ -0,0 +1,261 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// PipelineStage configures one analyzer in a Pipeline.
+type PipelineStage struct {
+	Analyzer Analyzer
+	Timeout  time.Duration // zero means no limit
+
+	// FailClosed treats a failed or timed-out stage as having found the
+	// entry sensitive, so content is never stored unchecked.
+	FailClosed bool
+
+	// StopOnSensitive skips the remaining stages once this stage finds the
+	// entry sensitive.
+	StopOnSensitive bool
+}
+
+// Pipeline runs an ordered chain of analyzers and merges their findings
+// into one Analysis.
+type Pipeline struct {
+	stages []PipelineStage
+}
+
+func NewPipeline(stages ...PipelineStage) *Pipeline {
+	return &Pipeline{stages: stages}
+}
+
+// NewDefaultPipeline checks sensitivity first, failing closed, then
+// classifies the content and cleans URLs. Blocked entries are still
+// classified so the vault can show what they were.
+func NewDefaultPipeline(cfg config.AnalyzerConfig) (*Pipeline, error) {
+	sensitive, err := NewSensitiveAnalyzer(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPipeline(
+		PipelineStage{Analyzer: sensitive, Timeout: cfg.StageTimeout, FailClosed: true},
+		PipelineStage{Analyzer: NewContentAnalyzer(), Timeout: cfg.StageTimeout},
+		PipelineStage{Analyzer: NewURLAnalyzer(cfg), Timeout: cfg.StageTimeout},
+	), nil
+}
+
+func (p *Pipeline) Name() string {
+	return "pipeline"
+}
+
+func (p *Pipeline) Analyze(ctx context.Context, entry *domain.ClipboardEntry) *domain.Analysis {
+	merged := &domain.Analysis{}
+
+	for _, stage := range p.stages {
+		name := stage.Analyzer.Name()
+
+		result, err := runStage(ctx, stage, entry)
+		if err != nil {
+			merged.Findings = append(merged.Findings, domain.Finding{
+				Analyzer: name,
+				Kind:     domain.FindingError,
+				Value:    err.Error(),
+			})
+			if !stage.FailClosed {
+				continue
+			}
+			result = &domain.Analysis{
+				IsSensitive: true,
+				Reason:      name + "_failed",
+				Severity:    domain.SeverityHigh,
+				Confidence:  domain.ConfidenceLow,
+			}
+		}
+
+		mergeAnalysis(merged, name, result)
+
+		if stage.StopOnSensitive && result.IsSensitive {
+			break
+		}
+	}
+
+	summarizeFindings(merged)
+	return merged
+}
+
+// runStage runs one analyzer under its timeout. A stage that overruns is
+// abandoned; analyzers check ctx between their steps, so it stops soon
+// after. A result that arrives once ctx is done may be incomplete and is
+// treated as a timeout too. The stage gets its own copy of entry, since the
+// caller goes on to change the entry while an abandoned stage may still be
+// reading it.
+func runStage(ctx context.Context, stage PipelineStage, entry *domain.ClipboardEntry) (*domain.Analysis, error) {
+	if stage.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, stage.Timeout)
+		defer cancel()
+	}
+
+	type outcome struct {
+		analysis *domain.Analysis
+		err      error
+	}
+	done := make(chan outcome, 1)
+	copied := *entry
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{err: fmt.Errorf("panic: %v", r)}
+			}
+		}()
+		done <- outcome{analysis: stage.Analyzer.Analyze(ctx, &copied)}
+	}()
+
+	var out outcome
+	select {
+	case out = <-done:
+	case <-ctx.Done():
+	}
+
+	if err := ctx.Err(); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %s", stage.Timeout)
+		}
+		return nil, err
+	}
+	if out.err == nil && out.analysis == nil {
+		out.err = errors.New("no analysis returned")
+	}
+	return out.analysis, out.err
+}
+
+// mergeAnalysis folds one stage's result into the pipeline's. The most
+// severe verdict wins, and the shortest TTL.
+func mergeAnalysis(merged *domain.Analysis, name string, result *domain.Analysis) {
+	for _, finding := range result.Findings {
+		if finding.Analyzer == "" {
+			finding.Analyzer = name
+		}
+		merged.Findings = append(merged.Findings, finding)
+	}
+
+	// Stages that only fill in the summary fields still get findings.
+	if result.Type != "" && !hasFinding(result.Findings, domain.FindingType) {
+		merged.Findings = append(merged.Findings, domain.Finding{Analyzer: name, Kind: domain.FindingType, Value: string(result.Type), Score: 1})
+	}
+	if result.Language != "" && !hasFinding(result.Findings, domain.FindingLanguage) {
+		merged.Findings = append(merged.Findings, domain.Finding{Analyzer: name, Kind: domain.FindingLanguage, Value: result.Language, Score: 1})
+	}
+	for _, tag := range result.Tags {
+		if !slices.ContainsFunc(result.Findings, func(f domain.Finding) bool { return f.Kind == domain.FindingTag && f.Value == tag }) {
+			merged.Findings = append(merged.Findings, domain.Finding{Analyzer: name, Kind: domain.FindingTag, Value: tag, Score: 1})
+		}
+	}
+
+	for _, match := range result.Matches {
+		if match.Analyzer == "" {
+			match.Analyzer = name
+		}
+		merged.Matches = append(merged.Matches, match)
+	}
+
+	if result.TTL > 0 && (merged.TTL == 0 || result.TTL < merged.TTL) {
+		merged.TTL = result.TTL
+	}
+
+	if takesVerdict(result, merged) {
+		merged.IsSensitive = result.IsSensitive
+		merged.Reason = result.Reason
+		merged.Severity = result.Severity
+		merged.Confidence = result.Confidence
+	}
+}
+
+// takesVerdict reports whether result's reason should replace merged's:
+// a sensitive verdict beats a non-sensitive one, then severity and
+// confidence decide.
+func takesVerdict(result, merged *domain.Analysis) bool {
+	switch {
+	case result.IsSensitive != merged.IsSensitive:
+		return result.IsSensitive
+	case result.Reason == "":
+		return false
+	case merged.Reason == "":
+		return true
+	case result.Severity.Rank() != merged.Severity.Rank():
+		return result.Severity.Rank() > merged.Severity.Rank()
+	}
+	return result.Confidence.Rank() > merged.Confidence.Rank()
+}
+
+func hasFinding(findings []domain.Finding, kind domain.FindingKind) bool {
+	return slices.ContainsFunc(findings, func(f domain.Finding) bool { return f.Kind == kind })
+}
+
+// summarizeFindings picks the best-scoring type and language, earlier
+// stages winning ties, collects the distinct tags and takes the first
+// domain and cleaned URL.
+func summarizeFindings(analysis *domain.Analysis) {
+	typeScore, langScore := -1.0, -1.0
+	for _, finding := range analysis.Findings {
+		switch finding.Kind {
+		case domain.FindingType:
+			if finding.Score > typeScore {
+				analysis.Type, typeScore = domain.ContentType(finding.Value), finding.Score
+			}
+		case domain.FindingLanguage:
+			if finding.Score > langScore {
+				analysis.Language, langScore = finding.Value, finding.Score
+			}
+		case domain.FindingTag:
+			if !slices.Contains(analysis.Tags, finding.Value) {
+				analysis.Tags = append(analysis.Tags, finding.Value)
+			}
+		case domain.FindingDomain:
+			if analysis.Domain == "" {
+				analysis.Domain = finding.Value
+			}
+		case domain.FindingURL:
+			if analysis.CleanURL == "" {
+				analysis.CleanURL = finding.Value
+			}
+		}
+	}
+}
+
+// Rules lists the rules of every stage that has them.
+func (p *Pipeline) Rules() []domain.Rule {
+	var rules []domain.Rule
+	for _, stage := range p.stages {
+		if engine, ok := stage.Analyzer.(ruleEngine); ok {
+			rules = append(rules, engine.Rules()...)
+		}
+	}
+	return rules
+}
+
+// ReloadRules reloads every stage that has rules, reporting all failures.
+func (p *Pipeline) ReloadRules() error {
+	var errs []error
+	for _, stage := range p.stages {
+		if engine, ok := stage.Analyzer.(ruleEngine); ok {
+			if err := engine.ReloadRules(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type ruleEngine interface {
+	Rules() []domain.Rule
+	ReloadRules() error
+}
@@ -0,0 +1,239 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// builtinRules are always loaded first, with the default action. A rules
+// file can replace one by reusing its name, or switch it off with
+// "disabled": true.
+var builtinRules = []domain.Rule{
+	{
+		Name:     "password",
+		Pattern:  `(?i)(password|passwd|pwd)\s*[:=]\s*\S+`,
+		Severity: domain.SeverityHigh,
+		Builtin:  true,
+	},
+	{
+		Name:     "token",
+		Pattern:  `(?i)(token|bearer)\s*[:=]?\s*[A-Za-z0-9_-]{20,}`,
+		Severity: domain.SeverityHigh,
+		Builtin:  true,
+	},
+	{
+		Name:     "api_key",
+		Pattern:  `(?i)(api[_-]?key|secret[_-]?key)\s*[:=]\s*\S+`,
+		Severity: domain.SeverityHigh,
+		Builtin:  true,
+	},
+}
+
+var ruleNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+type rulesFile struct {
+	Rules []ruleSpec `json:"rules"`
+}
+
+type ruleSpec struct {
+	Name     string            `json:"name"`
+	Pattern  string            `json:"pattern,omitempty"`
+	Keywords []string          `json:"keywords,omitempty"`
+	Severity domain.Severity   `json:"severity,omitempty"`
+	Action   domain.RuleAction `json:"action,omitempty"`
+	Disabled bool              `json:"disabled,omitempty"`
+}
+
+type compiledRule struct {
+	domain.Rule
+	re *regexp.Regexp
+}
+
+// LoadRules reads a JSON rules file and merges it over the built-in rules.
+// An empty path yields the built-in rules only. Rules without an action get
+// defaultAction.
+func LoadRules(path string, defaultAction domain.RuleAction) ([]domain.Rule, error) {
+	rules := append([]domain.Rule(nil), builtinRules...)
+	for i := range rules {
+		rules[i].Action = defaultAction
+	}
+	if path == "" {
+		return rules, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	var file rulesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file %s: %w", path, err)
+	}
+
+	seen := make(map[string]bool, len(file.Rules))
+	for i, spec := range file.Rules {
+		if !ruleNamePattern.MatchString(spec.Name) {
+			return nil, fmt.Errorf("rule %d: invalid name %q", i+1, spec.Name)
+		}
+		if seen[spec.Name] {
+			return nil, fmt.Errorf("rule %q: defined more than once", spec.Name)
+		}
+		seen[spec.Name] = true
+
+		idx := -1
+		for j, rule := range rules {
+			if rule.Name == spec.Name {
+				idx = j
+				break
+			}
+		}
+
+		if spec.Disabled {
+			if idx >= 0 {
+				rules = append(rules[:idx], rules[idx+1:]...)
+			}
+			continue
+		}
+
+		rule, err := spec.toRule(defaultAction)
+		if err != nil {
+			return nil, err
+		}
+
+		if idx >= 0 {
+			rules[idx] = rule
+		} else {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+func (spec ruleSpec) toRule(defaultAction domain.RuleAction) (domain.Rule, error) {
+	rule := domain.Rule{
+		Name:     spec.Name,
+		Pattern:  spec.Pattern,
+		Keywords: spec.Keywords,
+		Severity: spec.Severity,
+		Action:   spec.Action,
+	}
+
+	if (rule.Pattern == "") == (len(rule.Keywords) == 0) {
+		return rule, fmt.Errorf("rule %q: exactly one of pattern or keywords is required", rule.Name)
+	}
+	for _, keyword := range rule.Keywords {
+		if strings.TrimSpace(keyword) == "" {
+			return rule, fmt.Errorf("rule %q: keywords cannot be empty", rule.Name)
+		}
+	}
+
+	if rule.Severity == "" {
+		rule.Severity = domain.SeverityMedium
+	}
+	if rule.Severity.Rank() == 0 {
+		return rule, fmt.Errorf("rule %q: unknown severity %q", rule.Name, rule.Severity)
+	}
+
+	switch rule.Action {
+	case "":
+		rule.Action = defaultAction
+	case domain.ActionBlock, domain.ActionRedact, domain.ActionAllow, domain.ActionShortTTL:
+	default:
+		return rule, fmt.Errorf("rule %q: unknown action %q", rule.Name, rule.Action)
+	}
+
+	return rule, nil
+}
+
+func compileRules(rules []domain.Rule) ([]compiledRule, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+
+	for _, rule := range rules {
+		pattern := rule.Pattern
+		if pattern == "" {
+			// Keyword rules match the whole line around the keyword, since
+			// the keyword alone is rarely the part worth hiding.
+			quoted := make([]string, len(rule.Keywords))
+			for i, keyword := range rule.Keywords {
+				quoted[i] = regexp.QuoteMeta(keyword)
+			}
+			pattern = `(?i)[^\n]*(?:` + strings.Join(quoted, "|") + `)[^\n]*`
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: invalid pattern: %w", rule.Name, err)
+		}
+
+		compiled = append(compiled, compiledRule{Rule: rule, re: re})
+	}
+
+	return compiled, nil
+}
+
+// matchRules returns the spans matched by block and redact rules and by the
+// detectors, minus any that overlap a span matched by an allow rule. It stops
+// between rules and detectors once ctx is done, returning what it found so
+// far.
+func matchRules(ctx context.Context, rules []compiledRule, detectors []detector, content string) []domain.Match {
+	var allowed [][]int
+	for _, rule := range rules {
+		if rule.Action == domain.ActionAllow {
+			allowed = append(allowed, rule.re.FindAllStringIndex(content, -1)...)
+		}
+	}
+
+	var matches []domain.Match
+	for _, rule := range rules {
+		if ctx.Err() != nil {
+			return matches
+		}
+		if rule.Action == domain.ActionAllow {
+			continue
+		}
+
+		for _, loc := range rule.re.FindAllStringIndex(content, -1) {
+			if loc[0] == loc[1] || overlapsAny(loc, allowed) {
+				continue
+			}
+			matches = append(matches, domain.Match{
+				Rule:       rule.Name,
+				Severity:   rule.Severity,
+				Confidence: domain.ConfidenceMedium,
+				Action:     rule.Action,
+				Start:      loc[0],
+				End:        loc[1],
+			})
+		}
+	}
+
+	for _, d := range detectors {
+		if ctx.Err() != nil {
+			return matches
+		}
+		for _, match := range d.Detect(content) {
+			if !overlapsAny([]int{match.Start, match.End}, allowed) {
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	return matches
+}
+
+func overlapsAny(loc []int, spans [][]int) bool {
+	for _, span := range spans {
+		if loc[0] < span[1] && span[0] < loc[1] {
+			return true
+		}
+	}
+	return false
+}
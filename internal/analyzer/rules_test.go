@@ -0,0 +1,165 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+const testRules = `{
+  "rules": [
+    {"name": "acme_token", "pattern": "acme_[a-z0-9]{8}", "severity": "critical", "action": "block"},
+    {"name": "customer", "keywords": ["CUST-"], "severity": "low", "action": "redact"},
+    {"name": "acme_test", "pattern": "acme_test[a-z0-9]*", "action": "allow"},
+    {"name": "token", "disabled": true}
+  ]
+}`
+
+func writeRules(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write rules: %v", err)
+	}
+	return path
+}
+
+func TestAnalyze(t *testing.T) {
+	a, err := NewSensitiveAnalyzer(config.AnalyzerConfig{RulesFile: writeRules(t, testRules)})
+	if err != nil {
+		t.Fatalf("failed to create analyzer: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		content       string
+		wantSensitive bool
+		wantReason    string
+		wantMatches   []string
+	}{
+		{
+			name:    "plain text",
+			content: "hello world",
+		},
+		{
+			name:          "builtin rule",
+			content:       "password: hunter2",
+			wantSensitive: true,
+			wantReason:    "password",
+			wantMatches:   []string{"password"},
+		},
+		{
+			name:          "custom rule",
+			content:       "deploy with acme_3f9a01bc",
+			wantSensitive: true,
+			wantReason:    "acme_token",
+			wantMatches:   []string{"acme_token"},
+		},
+		{
+			name:          "most severe rule wins",
+			content:       "password=x acme_3f9a01bc",
+			wantSensitive: true,
+			wantReason:    "acme_token",
+			wantMatches:   []string{"password", "acme_token"},
+		},
+		{
+			name:    "allow rule suppresses overlapping match",
+			content: "acme_test1234",
+		},
+		{
+			name:        "redact rule is not sensitive",
+			content:     "ticket\nfrom CUST-42\nthanks",
+			wantMatches: []string{"customer"},
+		},
+		{
+			name:    "disabled builtin",
+			content: "token: abcdefghijklmnopqrstuvwxyz",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := a.Analyze(context.Background(), &domain.ClipboardEntry{Content: tt.content})
+
+			if got.IsSensitive != tt.wantSensitive {
+				t.Errorf("expected sensitive=%v, got %v", tt.wantSensitive, got.IsSensitive)
+			}
+			if got.Reason != tt.wantReason {
+				t.Errorf("expected reason %q, got %q", tt.wantReason, got.Reason)
+			}
+			if len(got.Matches) != len(tt.wantMatches) {
+				t.Fatalf("expected %d matches, got %+v", len(tt.wantMatches), got.Matches)
+			}
+			for i, rule := range tt.wantMatches {
+				if got.Matches[i].Rule != rule {
+					t.Errorf("expected match %d from %q, got %q", i, rule, got.Matches[i].Rule)
+				}
+			}
+		})
+	}
+
+	// Keyword rules cover the whole line containing the keyword.
+	got := a.Analyze(context.Background(), &domain.ClipboardEntry{Content: "ticket\nfrom CUST-42\nthanks"})
+	if m := got.Matches[0]; m.Start != 7 || m.End != 19 {
+		t.Errorf("expected keyword span [7:19], got [%d:%d]", m.Start, m.End)
+	}
+}
+
+func TestLoadRulesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+	}{
+		{name: "invalid json", rules: `{"rules": [`},
+		{name: "invalid name", rules: `{"rules": [{"name": "a b", "pattern": "x"}]}`},
+		{name: "duplicate name", rules: `{"rules": [{"name": "a", "pattern": "x"}, {"name": "a", "pattern": "y"}]}`},
+		{name: "no matcher", rules: `{"rules": [{"name": "a"}]}`},
+		{name: "both matchers", rules: `{"rules": [{"name": "a", "pattern": "x", "keywords": ["y"]}]}`},
+		{name: "unknown severity", rules: `{"rules": [{"name": "a", "pattern": "x", "severity": "extreme"}]}`},
+		{name: "unknown action", rules: `{"rules": [{"name": "a", "pattern": "x", "action": "ignore"}]}`},
+		{name: "invalid pattern", rules: `{"rules": [{"name": "a", "pattern": "("}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSensitiveAnalyzer(config.AnalyzerConfig{RulesFile: writeRules(t, tt.rules)}); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestReloadRulesKeepsPreviousOnError(t *testing.T) {
+	path := writeRules(t, testRules)
+
+	a, err := NewSensitiveAnalyzer(config.AnalyzerConfig{RulesFile: path})
+	if err != nil {
+		t.Fatalf("failed to create analyzer: %v", err)
+	}
+	before := len(a.Rules())
+
+	if err := os.WriteFile(path, []byte(`{"rules": [{"name": "a", "pattern": "("}]}`), 0o600); err != nil {
+		t.Fatalf("failed to write rules: %v", err)
+	}
+	if err := a.ReloadRules(); err == nil {
+		t.Fatal("expected reload error, got nil")
+	}
+	if got := len(a.Rules()); got != before {
+		t.Errorf("expected %d rules after failed reload, got %d", before, got)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"rules": []}`), 0o600); err != nil {
+		t.Fatalf("failed to write rules: %v", err)
+	}
+	if err := a.ReloadRules(); err != nil {
+		t.Fatalf("expected reload to succeed, got %v", err)
+	}
+	if got, want := len(a.Rules()), len(builtinRules)+len(a.detectors); got != want {
+		t.Errorf("expected %d built-in rules and detectors, got %d", want, got)
+	}
+}
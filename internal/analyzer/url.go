@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// URLAnalyzer records the domain of web URLs and, when cleaning is on,
+// their canonical form without tracking parameters.
+type URLAnalyzer struct {
+	clean    bool
+	params   map[string]bool
+	prefixes []string
+}
+
+// NewURLAnalyzer takes the tracking parameters to strip from the config.
+// A trailing "*" makes a parameter a prefix, as in "utm_*". Matching
+// ignores case.
+func NewURLAnalyzer(cfg config.AnalyzerConfig) *URLAnalyzer {
+	a := &URLAnalyzer{
+		clean:  cfg.CleanURLs,
+		params: make(map[string]bool),
+	}
+	for _, param := range cfg.TrackingParams {
+		param = strings.ToLower(strings.TrimSpace(param))
+		if prefix, ok := strings.CutSuffix(param, "*"); ok {
+			a.prefixes = append(a.prefixes, prefix)
+		} else if param != "" {
+			a.params[param] = true
+		}
+	}
+	return a
+}
+
+func (a *URLAnalyzer) Name() string {
+	return "url"
+}
+
+func (a *URLAnalyzer) Analyze(ctx context.Context, entry *domain.ClipboardEntry) *domain.Analysis {
+	analysis := &domain.Analysis{}
+
+	content := strings.TrimSpace(entry.Content)
+	if scoreURL(content) < minTypeScore {
+		return analysis
+	}
+
+	u, err := url.Parse(content)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return analysis
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	analysis.Findings = append(analysis.Findings, domain.Finding{
+		Analyzer: a.Name(),
+		Kind:     domain.FindingDomain,
+		Value:    host,
+		Score:    1,
+	})
+
+	if a.clean {
+		analysis.Findings = append(analysis.Findings, domain.Finding{
+			Analyzer: a.Name(),
+			Kind:     domain.FindingURL,
+			Value:    a.cleanURL(u),
+			Score:    1,
+		})
+	}
+
+	return analysis
+}
+
+// cleanURL lowercases the host, drops the default port, trims a trailing
+// slash from the path (an empty path becomes "/") and removes tracking
+// parameters. The order and encoding of the remaining parameters are kept.
+func (a *URLAnalyzer) cleanURL(u *url.URL) string {
+	cleaned := *u
+
+	cleaned.Host = strings.ToLower(cleaned.Host)
+	switch {
+	case cleaned.Scheme == "http" && strings.HasSuffix(cleaned.Host, ":80"):
+		cleaned.Host = strings.TrimSuffix(cleaned.Host, ":80")
+	case cleaned.Scheme == "https" && strings.HasSuffix(cleaned.Host, ":443"):
+		cleaned.Host = strings.TrimSuffix(cleaned.Host, ":443")
+	}
+
+	if len(cleaned.Path) > 1 {
+		cleaned.Path = strings.TrimSuffix(cleaned.Path, "/")
+		cleaned.RawPath = strings.TrimSuffix(cleaned.RawPath, "/")
+	}
+	if cleaned.Path == "" {
+		cleaned.Path = "/"
+	}
+
+	var kept []string
+	for _, pair := range strings.Split(cleaned.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key, _, _ := strings.Cut(pair, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if !a.isTracking(strings.ToLower(key)) {
+			kept = append(kept, pair)
+		}
+	}
+	cleaned.RawQuery = strings.Join(kept, "&")
+	cleaned.ForceQuery = false
+
+	return cleaned.String()
+}
+
+func (a *URLAnalyzer) isTracking(key string) bool {
+	if a.params[key] {
+		return true
+	}
+	for _, prefix := range a.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+func TestURLAnalyzer(t *testing.T) {
+	a := NewURLAnalyzer(config.Default().Analyzer)
+
+	tests := []struct {
+		name       string
+		content    string
+		wantDomain string
+		wantURL    string
+	}{
+		{
+			name:       "tracking parameters",
+			content:    "https://example.com/article?id=7&utm_source=news&utm_medium=email&fbclid=abc",
+			wantDomain: "example.com",
+			wantURL:    "https://example.com/article?id=7",
+		},
+		{
+			name:       "only tracking parameters",
+			content:    "https://example.com/?gclid=xyz",
+			wantDomain: "example.com",
+			wantURL:    "https://example.com/",
+		},
+		{
+			name:       "parameter case and encoding",
+			content:    "https://example.com/s?q=a%20b&UTM_Campaign=x&%66bclid=1",
+			wantDomain: "example.com",
+			wantURL:    "https://example.com/s?q=a%20b",
+		},
+		{
+			name:       "host case and default port",
+			content:    "HTTPS://WWW.Example.COM:443/Path/",
+			wantDomain: "www.example.com",
+			wantURL:    "https://www.example.com/Path",
+		},
+		{
+			name:       "non-default port kept",
+			content:    "http://localhost:8080",
+			wantDomain: "localhost",
+			wantURL:    "http://localhost:8080/",
+		},
+		{
+			name:       "http default port",
+			content:    "http://example.com:80/a/b/",
+			wantDomain: "example.com",
+			wantURL:    "http://example.com/a/b",
+		},
+		{
+			name:       "fragment kept",
+			content:    "https://example.com/docs/#install",
+			wantDomain: "example.com",
+			wantURL:    "https://example.com/docs#install",
+		},
+		{
+			name:       "surrounding whitespace",
+			content:    "  https://example.com/x?utm_id=1\n",
+			wantDomain: "example.com",
+			wantURL:    "https://example.com/x",
+		},
+		{name: "not a url", content: "hello world"},
+		{name: "url in a sentence", content: "see https://example.com"},
+		{name: "ssh url", content: "ssh://git@example.com/repo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPipeline(PipelineStage{Analyzer: a}).Analyze(context.Background(), &domain.ClipboardEntry{Content: tt.content})
+
+			if got.Domain != tt.wantDomain {
+				t.Errorf("expected domain %q, got %q", tt.wantDomain, got.Domain)
+			}
+			if got.CleanURL != tt.wantURL {
+				t.Errorf("expected URL %q, got %q", tt.wantURL, got.CleanURL)
+			}
+		})
+	}
+}
+
+func TestURLAnalyzerCleaningDisabled(t *testing.T) {
+	cfg := config.Default().Analyzer
+	cfg.CleanURLs = false
+
+	got := NewURLAnalyzer(cfg).Analyze(context.Background(), &domain.ClipboardEntry{Content: "https://example.com/?utm_source=x"})
+
+	if hasFinding(got.Findings, domain.FindingURL) {
+		t.Errorf("expected no cleaned URL, got %+v", got.Findings)
+	}
+	if !hasFinding(got.Findings, domain.FindingDomain) {
+		t.Errorf("expected the domain to be recorded, got %+v", got.Findings)
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+	"github.com/geodask/clipboard-manager/internal/service"
+)
+
+// POST /api/v1/analyze
+func (h *Handler) Analyze(w http.ResponseWriter, r *http.Request) {
+	var req AnalyzeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid JSON",
+		})
+		return
+	}
+
+	result, err := h.service.DryRun(r.Context(), &domain.ClipboardEntry{
+		Content:   req.Content,
+		SourceApp: req.SourceApp,
+	})
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newAnalyzeResponse(req.Content, result))
+}
+
+func newAnalyzeResponse(content string, result *service.DryRun) AnalyzeResponse {
+	resp := AnalyzeResponse{
+		Outcome:  "ignored",
+		Findings: []FindingResponse{},
+		Matches:  []MatchResponse{},
+	}
+
+	if result.IgnoredBy != "" {
+		resp.IgnoredBy = result.IgnoredBy
+		return resp
+	}
+
+	analysis := result.Analysis
+	resp.Type = string(analysis.Type)
+	resp.Language = analysis.Language
+	resp.Domain = analysis.Domain
+	resp.Tags = analysis.Tags
+	resp.Sensitive = analysis.IsSensitive
+	resp.Reason = analysis.Reason
+	resp.Severity = string(analysis.Severity)
+	resp.Confidence = string(analysis.Confidence)
+	if analysis.TTL > 0 {
+		resp.TTL = analysis.TTL.String()
+	}
+
+	switch {
+	case analysis.IsSensitive:
+		resp.Outcome = "blocked"
+	case len(result.Redactions) > 0:
+		resp.Outcome = "redacted"
+	default:
+		resp.Outcome = "stored"
+	}
+
+	if !analysis.IsSensitive && result.Content != content {
+		resp.Stored = result.Content
+		resp.OriginalURL = result.OriginalURL
+		for _, redaction := range result.Redactions {
+			resp.Redactions = append(resp.Redactions, RedactionResponse{
+				Rule:  redaction.Rule,
+				Start: redaction.Start,
+				End:   redaction.End,
+			})
+		}
+	}
+
+	for _, finding := range analysis.Findings {
+		resp.Findings = append(resp.Findings, FindingResponse{
+			Analyzer: finding.Analyzer,
+			Kind:     string(finding.Kind),
+			Value:    finding.Value,
+			Score:    finding.Score,
+		})
+	}
+
+	for _, match := range analysis.Matches {
+		resp.Matches = append(resp.Matches, MatchResponse{
+			Analyzer:   match.Analyzer,
+			Rule:       match.Rule,
+			Severity:   string(match.Severity),
+			Confidence: string(match.Confidence),
+			Action:     string(match.Action),
+			Start:      match.Start,
+			End:        match.End,
+			Text:       content[match.Start:match.End],
+		})
+	}
+
+	return resp
+}
@@ -29,6 +29,62 @@ func respondError(w http.ResponseWriter, err error) {
 		statusCode = http.StatusBadRequest
 		message = "Search query cannot be empty"
 
+	case errors.Is(err, service.ErrInvalidPause):
+		statusCode = http.StatusBadRequest
+		message = "Invalid pause duration"
+
+	case errors.Is(err, service.ErrInvalidStatsWindow):
+		statusCode = http.StatusBadRequest
+		message = "Invalid stats window or bucket"
+
+	case errors.Is(err, service.ErrProfileNotFound):
+		statusCode = http.StatusNotFound
+		message = "Profile not found"
+
+	case errors.Is(err, service.ErrProfileExists):
+		statusCode = http.StatusConflict
+		message = "Profile already exists"
+
+	case errors.Is(err, service.ErrInvalidProfileName):
+		statusCode = http.StatusBadRequest
+		message = "Invalid profile name"
+
+	case errors.Is(err, service.ErrInvalidRetention):
+		statusCode = http.StatusBadRequest
+		message = "Invalid retention"
+
+	case errors.Is(err, service.ErrProfileInUse):
+		statusCode = http.StatusConflict
+		message = "Cannot delete the default or active profile"
+
+	case errors.Is(err, service.ErrProfilesDisabled):
+		statusCode = http.StatusNotImplemented
+		message = "Profiles are not enabled"
+
+	case errors.Is(err, service.ErrNoMaintenanceRun):
+		statusCode = http.StatusNotFound
+		message = "No maintenance run recorded yet"
+
+	case errors.Is(err, service.ErrMaintenanceInProgress):
+		statusCode = http.StatusConflict
+		message = "Maintenance already in progress"
+
+	case errors.Is(err, service.ErrRulesUnsupported):
+		statusCode = http.StatusNotImplemented
+		message = "Analyzer does not support rules"
+
+	case errors.Is(err, service.ErrInvalidRules):
+		statusCode = http.StatusUnprocessableEntity
+		message = err.Error()
+
+	case errors.Is(err, service.ErrVaultDisabled):
+		statusCode = http.StatusNotImplemented
+		message = "Vault is not enabled (start clipd with --vault-enabled)"
+
+	case errors.Is(err, service.ErrVaultEntryNotFound):
+		statusCode = http.StatusNotFound
+		message = "Vault entry not found or expired"
+
 	case errors.Is(err, service.ErrEmptyContent):
 		statusCode = http.StatusBadRequest
 		message = "Content cannot be empty"
@@ -37,6 +93,34 @@ func respondError(w http.ResponseWriter, err error) {
 		statusCode = http.StatusBadRequest
 		message = "Content contains sensitive data"
 
+	case errors.Is(err, service.ErrIgnoredContent):
+		statusCode = http.StatusBadRequest
+		message = "Content matches an ignore rule"
+
+	case errors.Is(err, service.ErrClipboardUnavailable):
+		statusCode = http.StatusNotImplemented
+		message = "Clipboard writing is not available"
+
+	case errors.Is(err, service.ErrInvalidSelection):
+		statusCode = http.StatusBadRequest
+		message = "Invalid selection (want clipboard, primary or secondary)"
+
+	case errors.Is(err, service.ErrClipboardWrite):
+		statusCode = http.StatusServiceUnavailable
+		message = err.Error()
+
+	case errors.Is(err, service.ErrNoTransforms):
+		statusCode = http.StatusBadRequest
+		message = "At least one transform is required"
+
+	case errors.Is(err, service.ErrUnknownTransform):
+		statusCode = http.StatusBadRequest
+		message = err.Error()
+
+	case errors.Is(err, service.ErrTransformFailed):
+		statusCode = http.StatusUnprocessableEntity
+		message = err.Error()
+
 	default:
 		statusCode = http.StatusInternalServerError
 		message = "Internal server error"
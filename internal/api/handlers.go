@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/geodask/clipboard-manager/internal/domain"
@@ -14,21 +15,44 @@ import (
 
 type Service interface {
 	ProcessNewEntry(ctx context.Context, entry *domain.ClipboardEntry) (*domain.ClipboardEntry, error)
-	GetHistory(ctx context.Context, limit int) ([]*domain.ClipboardEntry, error)
-	GetEntry(ctx context.Context, id string) (*domain.ClipboardEntry, error)
-	DeleteEntry(ctx context.Context, id string) error
-	Search(ctx context.Context, query string, limit int) ([]*domain.ClipboardEntry, error)
-	ClearHistory(ctx context.Context) error
-	GetStats(ctx context.Context) (*service.Stats, error)
+	GetHistory(ctx context.Context, profile string, filter domain.HistoryFilter, limit int) ([]*domain.ClipboardEntry, error)
+	GetEntry(ctx context.Context, profile, id string) (*domain.ClipboardEntry, error)
+	CopyEntry(ctx context.Context, profile, id, selection string) (*domain.ClipboardEntry, error)
+	DeleteEntry(ctx context.Context, profile, id string) error
+	Search(ctx context.Context, profile, query string, limit int) ([]*domain.ClipboardEntry, error)
+	ClearHistory(ctx context.Context, profile string) error
+	GetStats(ctx context.Context, profile string, opts service.StatsOptions) (*service.Stats, error)
+	ListProfiles(ctx context.Context) ([]*domain.Profile, error)
+	CreateProfile(ctx context.Context, name string, retentionMaxAge time.Duration) (*domain.Profile, error)
+	DeleteProfile(ctx context.Context, name string) error
+	ActivateProfile(ctx context.Context, name string) (*domain.Profile, error)
+	ActiveProfile() string
+	RunMaintenance(ctx context.Context) (*domain.MaintenanceReport, error)
+	LastMaintenance(ctx context.Context) (*domain.MaintenanceReport, error)
+	ListRules(ctx context.Context) ([]domain.Rule, error)
+	ReloadRules(ctx context.Context) ([]domain.Rule, error)
+	DryRun(ctx context.Context, entry *domain.ClipboardEntry) (*service.DryRun, error)
+	TransformEntry(ctx context.Context, profile, id string, names []string, store bool) (*service.Transformed, error)
+	ListVault(ctx context.Context) ([]domain.VaultEntry, error)
+	RevealVaultEntry(ctx context.Context, id string) (domain.VaultEntry, string, error)
+	CopyVaultEntry(ctx context.Context, id, selection string) (domain.VaultEntry, error)
+	DeleteVaultEntry(ctx context.Context, id string) error
+	PurgeVault() int
+	Pause(ctx context.Context, d time.Duration) (domain.PauseState, error)
+	Resume(ctx context.Context) (domain.PauseState, error)
+	PauseState() domain.PauseState
+	MonitorStatus() []domain.MonitorStatus
 }
 
 type Handler struct {
-	service Service
+	service   Service
+	startTime time.Time
 }
 
 func NewHandler(service Service) *Handler {
 	return &Handler{
-		service: service,
+		service:   service,
+		startTime: time.Now(),
 	}
 }
 
@@ -47,18 +71,20 @@ func (h *Handler) GetHistory(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	entries, err := h.service.GetHistory(r.Context(), limit)
+	filter := domain.HistoryFilter{
+		Language: r.URL.Query().Get("lang"),
+		Domain:   r.URL.Query().Get("domain"),
+	}
+
+	entries, err := h.service.GetHistory(r.Context(), r.URL.Query().Get("profile"), filter, limit)
 	if err != nil {
 		respondError(w, err)
+		return
 	}
 
 	var entryResponses []EntryResponse
 	for _, entry := range entries {
-		entryResponses = append(entryResponses, EntryResponse{
-			Id:        entry.Id,
-			Content:   entry.Content,
-			Timestamp: entry.Timestamp,
-		})
+		entryResponses = append(entryResponses, newEntryResponse(entry))
 	}
 
 	respondJSON(w, http.StatusOK, HistoryResponse{
@@ -75,17 +101,31 @@ func (h *Handler) GetEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	entry, err := h.service.GetEntry(r.Context(), id)
+	entry, err := h.service.GetEntry(r.Context(), r.URL.Query().Get("profile"), id)
 	if err != nil {
 		respondError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, EntryResponse{
-		Id:        entry.Id,
-		Content:   entry.Content,
-		Timestamp: entry.Timestamp,
-	})
+	respondJSON(w, http.StatusOK, newEntryResponse(entry))
+}
+
+// POST /api/v1/history/{id}/copy?profile=work&selection=primary
+func (h *Handler) CopyEntry(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if id == "" {
+		respondError(w, service.ErrInvalidId)
+		return
+	}
+
+	entry, err := h.service.CopyEntry(r.Context(), r.URL.Query().Get("profile"), id, r.URL.Query().Get("selection"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newEntryResponse(entry))
 }
 
 func (h *Handler) DeleteEntry(w http.ResponseWriter, r *http.Request) {
@@ -96,7 +136,7 @@ func (h *Handler) DeleteEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.service.DeleteEntry(r.Context(), id)
+	err := h.service.DeleteEntry(r.Context(), r.URL.Query().Get("profile"), id)
 	if err != nil {
 		respondError(w, err)
 		return
@@ -121,6 +161,8 @@ func (h *Handler) CreateEntry(w http.ResponseWriter, r *http.Request) {
 	// Create entry
 	entry := &domain.ClipboardEntry{
 		Content:   req.Content,
+		Profile:   r.URL.Query().Get("profile"),
+		SourceApp: req.SourceApp,
 		Timestamp: time.Now(),
 	}
 
@@ -130,14 +172,10 @@ func (h *Handler) CreateEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondJSON(w, http.StatusCreated, EntryResponse{
-		Id:        stored.Id,
-		Content:   stored.Content,
-		Timestamp: stored.Timestamp,
-	})
+	respondJSON(w, http.StatusCreated, newEntryResponse(stored))
 }
 
-// GET /api/v1/search?q=query&limit=10
+// GET /api/v1/search?q=query&limit=10&profile=work
 func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
 	limitStr := r.URL.Query().Get("limit")
@@ -149,7 +187,7 @@ func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	entries, err := h.service.Search(r.Context(), query, limit)
+	entries, err := h.service.Search(r.Context(), r.URL.Query().Get("profile"), query, limit)
 	if err != nil {
 		respondError(w, err)
 		return
@@ -157,11 +195,7 @@ func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 
 	var entryResponses []EntryResponse
 	for _, entry := range entries {
-		entryResponses = append(entryResponses, EntryResponse{
-			Id:        entry.Id,
-			Content:   entry.Content,
-			Timestamp: entry.Timestamp,
-		})
+		entryResponses = append(entryResponses, newEntryResponse(entry))
 	}
 
 	respondJSON(w, http.StatusOK, HistoryResponse{
@@ -172,7 +206,7 @@ func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 
 // DELETE /api/v1/history
 func (h *Handler) ClearHistory(w http.ResponseWriter, r *http.Request) {
-	err := h.service.ClearHistory(r.Context())
+	err := h.service.ClearHistory(r.Context(), r.URL.Query().Get("profile"))
 	if err != nil {
 		respondError(w, err)
 		return
@@ -183,16 +217,176 @@ func (h *Handler) ClearHistory(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// GET /api/v1/stats
+// GET /api/v1/stats?bucket=day&window=7d
 func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
-	stats, err := h.service.GetStats(r.Context())
+	var opts service.StatsOptions
+
+	switch bucket := r.URL.Query().Get("bucket"); bucket {
+	case "", "day":
+		opts.Bucket = 24 * time.Hour
+	case "hour":
+		opts.Bucket = time.Hour
+	default:
+		respondError(w, service.ErrInvalidStatsWindow)
+		return
+	}
+
+	if windowStr := r.URL.Query().Get("window"); windowStr != "" {
+		window, err := parseWindow(windowStr)
+		if err != nil {
+			respondError(w, service.ErrInvalidStatsWindow)
+			return
+		}
+		opts.Window = window
+	}
+
+	stats, err := h.service.GetStats(r.Context(), r.URL.Query().Get("profile"), opts)
 	if err != nil {
 		respondError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, StatsResponse{
-		TotalEntries: stats.TotalEntries,
-		Status:       "running",
-	})
+	resp := StatsResponse{
+		Profile:           stats.Profile,
+		TotalEntries:      stats.TotalEntries,
+		Status:            "running",
+		UptimeSeconds:     int64(time.Since(h.startTime).Seconds()),
+		TotalBytes:        stats.TotalBytes,
+		AverageBytes:      stats.AverageBytes,
+		CountsByType:      make(map[string]int, len(stats.CountsByType)),
+		LargestEntries:    []EntrySizeResponse{},
+		SensitiveSkipped:  stats.SensitiveSkipped,
+		SensitiveByReason: stats.SensitiveByReason,
+		IgnoredSkipped:    stats.IgnoredSkipped,
+		IgnoredByRule:     stats.IgnoredByRule,
+		DatabaseBytes:     stats.DatabaseBytes,
+		Activity: ActivityResponse{
+			Bucket:  bucketName(stats.Bucket),
+			Window:  stats.Window.String(),
+			Buckets: []ActivityBucketResponse{},
+		},
+	}
+
+	for contentType, count := range stats.CountsByType {
+		resp.CountsByType[string(contentType)] = count
+	}
+
+	for _, entry := range stats.Largest {
+		resp.LargestEntries = append(resp.LargestEntries, EntrySizeResponse{
+			Id:        entry.Id,
+			Type:      string(entry.Type),
+			Size:      entry.Size,
+			Timestamp: entry.Timestamp,
+		})
+	}
+
+	for _, bucket := range stats.Activity {
+		resp.Activity.Buckets = append(resp.Activity.Buckets, ActivityBucketResponse{
+			Start: bucket.Start,
+			Count: bucket.Count,
+		})
+	}
+
+	if !stats.Oldest.IsZero() {
+		resp.OldestEntry = &stats.Oldest
+	}
+	if !stats.Newest.IsZero() {
+		resp.NewestEntry = &stats.Newest
+	}
+
+	resp.Monitor = newMonitorStatusResponses(stats.Monitor)
+	resp.Paused = stats.Pause.Paused
+	if !stats.Pause.Until.IsZero() {
+		resp.PausedUntil = &stats.Pause.Until
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// parseWindow accepts Go durations plus a "d" suffix for whole days.
+func parseWindow(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func bucketName(bucket time.Duration) string {
+	if bucket == time.Hour {
+		return "hour"
+	}
+	return "day"
+}
+
+// GET /api/v1/admin/maintenance
+func (h *Handler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	report, err := h.service.LastMaintenance(r.Context())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newMaintenanceResponse(report))
+}
+
+// POST /api/v1/admin/maintenance
+func (h *Handler) RunMaintenance(w http.ResponseWriter, r *http.Request) {
+	report, err := h.service.RunMaintenance(r.Context())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newMaintenanceResponse(report))
+}
+
+func newMaintenanceResponse(report *domain.MaintenanceReport) MaintenanceResponse {
+	return MaintenanceResponse{
+		StartedAt:       report.StartedAt,
+		DurationMs:      report.Duration.Milliseconds(),
+		IntegrityOK:     report.IntegrityOK,
+		IntegrityErrors: report.IntegrityErrors,
+		FullVacuum:      report.FullVacuum,
+		SizeBefore:      report.SizeBefore,
+		SizeAfter:       report.SizeAfter,
+		ReclaimedBytes:  report.ReclaimedBytes(),
+	}
+}
+
+func newEntryResponse(entry *domain.ClipboardEntry) EntryResponse {
+	resp := EntryResponse{
+		Id:          entry.Id,
+		Content:     entry.Content,
+		Type:        string(entry.Type),
+		Language:    entry.Language,
+		Domain:      entry.Domain,
+		OriginalURL: entry.Metadata.OriginalURL,
+		Profile:     entry.Profile,
+		Timestamp:   entry.Timestamp,
+		Selection:   string(entry.Metadata.Selection),
+		Source:      entry.Metadata.Source,
+		UseCount:    entry.UseCount,
+	}
+	// Entries from other sources have no selection.
+	if resp.Selection == "" && (resp.Source == "" || resp.Source == "clipboard") {
+		resp.Selection = string(domain.SelectionClipboard)
+	}
+	if !entry.ExpiresAt.IsZero() {
+		resp.ExpiresAt = &entry.ExpiresAt
+	}
+	if !entry.LastUsedAt.IsZero() {
+		resp.LastUsedAt = &entry.LastUsedAt
+	}
+	for _, redaction := range entry.Metadata.Redactions {
+		resp.Redactions = append(resp.Redactions, RedactionResponse{
+			Rule:  redaction.Rule,
+			Start: redaction.Start,
+			End:   redaction.End,
+		})
+	}
+	return resp
 }
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+	"github.com/geodask/clipboard-manager/internal/service"
+)
+
+// MockService implements the methods a test sets; calling any other method
+// panics on the nil embedded Service.
+type MockService struct {
+	Service
+
+	GetHistoryFunc func(ctx context.Context, profile string, filter domain.HistoryFilter, limit int) ([]*domain.ClipboardEntry, error)
+	DryRunFunc     func(ctx context.Context, entry *domain.ClipboardEntry) (*service.DryRun, error)
+}
+
+func (m *MockService) GetHistory(ctx context.Context, profile string, filter domain.HistoryFilter, limit int) ([]*domain.ClipboardEntry, error) {
+	return m.GetHistoryFunc(ctx, profile, filter, limit)
+}
+
+func (m *MockService) DryRun(ctx context.Context, entry *domain.ClipboardEntry) (*service.DryRun, error) {
+	return m.DryRunFunc(ctx, entry)
+}
+
+func TestGetHistory(t *testing.T) {
+	svc := &MockService{
+		GetHistoryFunc: func(ctx context.Context, profile string, filter domain.HistoryFilter, limit int) ([]*domain.ClipboardEntry, error) {
+			if profile != "" && profile != domain.DefaultProfile {
+				return nil, service.ErrProfileNotFound
+			}
+			return []*domain.ClipboardEntry{{Id: "1", Content: "hello"}}, nil
+		},
+	}
+	routes := NewHandler(svc).Routes()
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantKey    string // a top-level key the one JSON body must have
+	}{
+		{name: "active profile", url: "/api/v1/history/", wantStatus: http.StatusOK, wantKey: "entries"},
+		{name: "unknown profile", url: "/api/v1/history/?profile=missing", wantStatus: http.StatusNotFound, wantKey: "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+
+			dec := json.NewDecoder(rec.Body)
+			var body map[string]any
+			if err := dec.Decode(&body); err != nil {
+				t.Fatalf("decoding body: %v", err)
+			}
+			if _, ok := body[tt.wantKey]; !ok {
+				t.Errorf("body = %v, want a %q key", body, tt.wantKey)
+			}
+			if dec.More() {
+				t.Error("expected a single JSON body")
+			}
+		})
+	}
+}
+
+func TestRulesTestMatchesAnalyze(t *testing.T) {
+	svc := &MockService{
+		DryRunFunc: func(ctx context.Context, entry *domain.ClipboardEntry) (*service.DryRun, error) {
+			return &service.DryRun{
+				Content: entry.Content,
+				Analysis: &domain.Analysis{
+					IsSensitive: true,
+					Reason:      "password",
+					Severity:    domain.SeverityHigh,
+					Matches:     []domain.Match{{Rule: "password", Action: domain.ActionBlock, Start: 0, End: 8}},
+				},
+			}, nil
+		},
+	}
+	routes := NewHandler(svc).Routes()
+
+	post := func(url string) string {
+		rec := httptest.NewRecorder()
+		routes.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, url, strings.NewReader(`{"content":"password=hunter2"}`)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("POST %s status = %d, want %d", url, rec.Code, http.StatusOK)
+		}
+		return rec.Body.String()
+	}
+
+	analyze := post("/api/v1/analyze")
+	if got := post("/api/v1/rules/test"); got != analyze {
+		t.Errorf("rules/test = %s, want the analyze response %s", got, analyze)
+	}
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+	"github.com/geodask/clipboard-manager/internal/service"
+)
+
+// GET /api/v1/monitor
+func (h *Handler) GetMonitor(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, h.newMonitorResponse(h.service.PauseState()))
+}
+
+// POST /api/v1/monitor/pause?for=10m
+func (h *Handler) PauseMonitor(w http.ResponseWriter, r *http.Request) {
+	var d time.Duration
+	if forStr := r.URL.Query().Get("for"); forStr != "" {
+		var err error
+		if d, err = parseWindow(forStr); err != nil || d <= 0 {
+			respondError(w, service.ErrInvalidPause)
+			return
+		}
+	}
+
+	state, err := h.service.Pause(r.Context(), d)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, h.newMonitorResponse(state))
+}
+
+// POST /api/v1/monitor/resume
+func (h *Handler) ResumeMonitor(w http.ResponseWriter, r *http.Request) {
+	state, err := h.service.Resume(r.Context())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, h.newMonitorResponse(state))
+}
+
+func (h *Handler) newMonitorResponse(state domain.PauseState) MonitorResponse {
+	resp := MonitorResponse{
+		Paused:  state.Paused,
+		Sources: newMonitorStatusResponses(h.service.MonitorStatus()),
+	}
+	if resp.Sources == nil {
+		resp.Sources = []MonitorStatusResponse{}
+	}
+	if state.Paused {
+		resp.PausedSince = &state.Since
+	}
+	if !state.Until.IsZero() {
+		resp.PausedUntil = &state.Until
+	}
+	return resp
+}
+
+func newMonitorStatusResponses(statuses []domain.MonitorStatus) []MonitorStatusResponse {
+	var resp []MonitorStatusResponse
+	for _, status := range statuses {
+		monitorResp := MonitorStatusResponse{
+			Selection:   string(status.Selection),
+			Backend:     status.Backend,
+			Mode:        status.Mode,
+			IntervalMs:  status.Interval.Milliseconds(),
+			ErrorStreak: status.ErrorStreak,
+			LastError:   status.LastError,
+		}
+		if !status.LastErrorAt.IsZero() {
+			monitorResp.LastErrorAt = &status.LastErrorAt
+		}
+		if !status.LastChangeAt.IsZero() {
+			monitorResp.LastChangeAt = &status.LastChangeAt
+		}
+		resp = append(resp, monitorResp)
+	}
+	return resp
+}
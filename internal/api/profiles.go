@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+	"github.com/geodask/clipboard-manager/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// GET /api/v1/profiles
+func (h *Handler) ListProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.service.ListProfiles(r.Context())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	resp := ProfilesResponse{
+		Profiles: []ProfileResponse{},
+		Active:   h.service.ActiveProfile(),
+	}
+	for _, profile := range profiles {
+		resp.Profiles = append(resp.Profiles, newProfileResponse(profile))
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// POST /api/v1/profiles
+func (h *Handler) CreateProfile(w http.ResponseWriter, r *http.Request) {
+	var req CreateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid JSON",
+		})
+		return
+	}
+
+	var retention time.Duration
+	if req.Retention != "" {
+		var err error
+		if retention, err = parseWindow(req.Retention); err != nil {
+			respondError(w, service.ErrInvalidRetention)
+			return
+		}
+	}
+
+	profile, err := h.service.CreateProfile(r.Context(), req.Name, retention)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, newProfileResponse(profile))
+}
+
+// DELETE /api/v1/profiles/{name}
+func (h *Handler) DeleteProfile(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteProfile(r.Context(), chi.URLParam(r, "name")); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, SuccessResponse{
+		Message: "Profile deleted successfully",
+	})
+}
+
+// POST /api/v1/profiles/{name}/activate
+func (h *Handler) ActivateProfile(w http.ResponseWriter, r *http.Request) {
+	profile, err := h.service.ActivateProfile(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newProfileResponse(profile))
+}
+
+func newProfileResponse(profile *domain.Profile) ProfileResponse {
+	resp := ProfileResponse{
+		Name:      profile.Name,
+		CreatedAt: profile.CreatedAt,
+		Active:    profile.Active,
+	}
+	if profile.RetentionMaxAge > 0 {
+		resp.RetentionMaxAge = profile.RetentionMaxAge.String()
+	}
+	return resp
+}
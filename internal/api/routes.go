@@ -20,17 +20,54 @@ func (h *Handler) Routes() http.Handler {
 		r.Route("/history", func(r chi.Router) {
 			r.Get("/", h.GetHistory)
 			r.Get("/{id}", h.GetEntry)
+			r.Post("/{id}/copy", h.CopyEntry)
+			r.Post("/{id}/transform", h.TransformEntry)
 
 			r.Delete("/", h.ClearHistory)
 			r.Delete("/{id}", h.DeleteEntry)
 		})
 
 		r.Post("/entries", h.CreateEntry)
+		r.Post("/analyze", h.Analyze)
+		r.Get("/transforms", h.ListTransforms)
 
 		r.Get("/search", h.Search)
 
 		r.Get("/stats", h.GetStats)
 
+		r.Route("/monitor", func(r chi.Router) {
+			r.Get("/", h.GetMonitor)
+			r.Post("/pause", h.PauseMonitor)
+			r.Post("/resume", h.ResumeMonitor)
+		})
+
+		r.Route("/profiles", func(r chi.Router) {
+			r.Get("/", h.ListProfiles)
+			r.Post("/", h.CreateProfile)
+			r.Delete("/{name}", h.DeleteProfile)
+			r.Post("/{name}/activate", h.ActivateProfile)
+		})
+
+		r.Route("/rules", func(r chi.Router) {
+			r.Get("/", h.ListRules)
+			r.Post("/reload", h.ReloadRules)
+			r.Post("/test", h.Analyze) // the same dry run as /analyze
+		})
+
+		r.Route("/vault", func(r chi.Router) {
+			r.Get("/", h.ListVault)
+			r.Get("/{id}", h.GetVaultEntry)
+			r.Post("/{id}/copy", h.CopyVaultEntry)
+
+			r.Delete("/", h.PurgeVault)
+			r.Delete("/{id}", h.DeleteVaultEntry)
+		})
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Get("/maintenance", h.GetMaintenance)
+			r.Post("/maintenance", h.RunMaintenance)
+		})
+
 	})
 
 	return r
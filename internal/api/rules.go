@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// GET /api/v1/rules
+func (h *Handler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.ListRules(r.Context())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newRulesResponse(rules))
+}
+
+// POST /api/v1/rules/reload
+func (h *Handler) ReloadRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.ReloadRules(r.Context())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newRulesResponse(rules))
+}
+
+func newRulesResponse(rules []domain.Rule) RulesResponse {
+	resp := RulesResponse{
+		Rules: make([]RuleResponse, 0, len(rules)),
+		Count: len(rules),
+	}
+	for _, rule := range rules {
+		resp.Rules = append(resp.Rules, RuleResponse{
+			Name:     rule.Name,
+			Pattern:  rule.Pattern,
+			Keywords: rule.Keywords,
+			Severity: string(rule.Severity),
+			Action:   string(rule.Action),
+			Builtin:  rule.Builtin,
+			Detector: rule.Detector,
+		})
+	}
+	return resp
+}
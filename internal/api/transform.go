@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/geodask/clipboard-manager/internal/service"
+	"github.com/geodask/clipboard-manager/internal/transform"
+	"github.com/go-chi/chi/v5"
+)
+
+// GET /api/v1/transforms
+func (h *Handler) ListTransforms(w http.ResponseWriter, r *http.Request) {
+	resp := TransformsResponse{Transforms: []TransformInfo{}}
+	for _, t := range transform.List() {
+		resp.Transforms = append(resp.Transforms, TransformInfo{
+			Name:        t.Name,
+			Description: t.Description,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// POST /api/v1/history/{id}/transform?profile=work
+func (h *Handler) TransformEntry(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if id == "" {
+		respondError(w, service.ErrInvalidId)
+		return
+	}
+
+	var req TransformRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid JSON",
+		})
+		return
+	}
+
+	result, err := h.service.TransformEntry(r.Context(), r.URL.Query().Get("profile"), id, req.Transforms, req.Store)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	resp := TransformResponse{
+		Result:     result.Content,
+		Transforms: req.Transforms,
+	}
+	status := http.StatusOK
+	if result.Entry != nil {
+		entry := newEntryResponse(result.Entry)
+		resp.Entry = &entry
+		status = http.StatusCreated
+	}
+
+	respondJSON(w, status, resp)
+}
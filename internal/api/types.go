@@ -12,13 +12,33 @@ type SearchRequest struct {
 }
 
 type CreateEntryRequest struct {
-	Content string `json:"content"`
+	Content   string `json:"content"`
+	SourceApp string `json:"source_app,omitempty"`
 }
 
 type EntryResponse struct {
-	Id        string    `json:"id"`
-	Content   string    `json:"content"`
-	Timestamp time.Time `json:"timestamp"`
+	Id          string              `json:"id"`
+	Content     string              `json:"content"`
+	Type        string              `json:"type,omitempty"`
+	Language    string              `json:"language,omitempty"`
+	Domain      string              `json:"domain,omitempty"`
+	OriginalURL string              `json:"original_url,omitempty"`
+	Profile     string              `json:"profile,omitempty"`
+	Selection   string              `json:"selection"`
+	Source      string              `json:"source,omitempty"`
+	Redactions  []RedactionResponse `json:"redactions,omitempty"`
+	Timestamp   time.Time           `json:"timestamp"`
+	ExpiresAt   *time.Time          `json:"expires_at,omitempty"`
+	UseCount    int                 `json:"use_count"`
+	LastUsedAt  *time.Time          `json:"last_used_at,omitempty"`
+}
+
+// RedactionResponse locates a redaction placeholder in the entry content,
+// as byte offsets.
+type RedactionResponse struct {
+	Rule  string `json:"rule"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
 }
 
 type HistoryResponse struct {
@@ -27,8 +47,191 @@ type HistoryResponse struct {
 }
 
 type StatsResponse struct {
-	TotalEntries int    `json:"total_entries"`
-	Status       string `json:"status"`
+	Profile           string                  `json:"profile"`
+	TotalEntries      int                     `json:"total_entries"`
+	Status            string                  `json:"status"`
+	UptimeSeconds     int64                   `json:"uptime_seconds"`
+	TotalBytes        int64                   `json:"total_bytes"`
+	AverageBytes      float64                 `json:"average_bytes"`
+	CountsByType      map[string]int          `json:"counts_by_type"`
+	LargestEntries    []EntrySizeResponse     `json:"largest_entries"`
+	Activity          ActivityResponse        `json:"activity"`
+	SensitiveSkipped  int                     `json:"sensitive_skipped"`
+	SensitiveByReason map[string]int          `json:"sensitive_by_reason"`
+	IgnoredSkipped    int                     `json:"ignored_skipped"`
+	IgnoredByRule     map[string]int          `json:"ignored_by_rule"`
+	OldestEntry       *time.Time              `json:"oldest_entry,omitempty"`
+	NewestEntry       *time.Time              `json:"newest_entry,omitempty"`
+	DatabaseBytes     int64                   `json:"database_bytes"`
+	Monitor           []MonitorStatusResponse `json:"monitor,omitempty"`
+	Paused            bool                    `json:"paused"`
+	PausedUntil       *time.Time              `json:"paused_until,omitempty"`
+}
+
+type MonitorResponse struct {
+	Paused      bool                    `json:"paused"`
+	PausedSince *time.Time              `json:"paused_since,omitempty"`
+	PausedUntil *time.Time              `json:"paused_until,omitempty"`
+	Sources     []MonitorStatusResponse `json:"sources"`
+}
+
+type MonitorStatusResponse struct {
+	Selection    string     `json:"selection"`
+	Backend      string     `json:"backend"`
+	Mode         string     `json:"mode"`
+	IntervalMs   int64      `json:"interval_ms,omitempty"`
+	ErrorStreak  int        `json:"error_streak"`
+	LastError    string     `json:"last_error,omitempty"`
+	LastErrorAt  *time.Time `json:"last_error_at,omitempty"`
+	LastChangeAt *time.Time `json:"last_change_at,omitempty"`
+}
+
+type EntrySizeResponse struct {
+	Id        string    `json:"id"`
+	Type      string    `json:"type"`
+	Size      int       `json:"size"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type ActivityResponse struct {
+	Bucket  string                   `json:"bucket"`
+	Window  string                   `json:"window"`
+	Buckets []ActivityBucketResponse `json:"buckets"`
+}
+
+type ActivityBucketResponse struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+type MaintenanceResponse struct {
+	StartedAt       time.Time `json:"started_at"`
+	DurationMs      int64     `json:"duration_ms"`
+	IntegrityOK     bool      `json:"integrity_ok"`
+	IntegrityErrors []string  `json:"integrity_errors,omitempty"`
+	FullVacuum      bool      `json:"full_vacuum"`
+	SizeBefore      int64     `json:"size_before"`
+	SizeAfter       int64     `json:"size_after"`
+	ReclaimedBytes  int64     `json:"reclaimed_bytes"`
+}
+
+type CreateProfileRequest struct {
+	Name      string `json:"name"`
+	Retention string `json:"retention,omitempty"` // e.g. "72h" or "30d"
+}
+
+type ProfileResponse struct {
+	Name            string    `json:"name"`
+	RetentionMaxAge string    `json:"retention_max_age,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	Active          bool      `json:"active"`
+}
+
+type ProfilesResponse struct {
+	Profiles []ProfileResponse `json:"profiles"`
+	Active   string            `json:"active"`
+}
+
+type RuleResponse struct {
+	Name     string   `json:"name"`
+	Pattern  string   `json:"pattern,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+	Severity string   `json:"severity"`
+	Action   string   `json:"action"`
+	Builtin  bool     `json:"builtin"`
+	Detector bool     `json:"detector"`
+}
+
+type RulesResponse struct {
+	Rules []RuleResponse `json:"rules"`
+	Count int            `json:"count"`
+}
+
+type MatchResponse struct {
+	Analyzer   string `json:"analyzer,omitempty"`
+	Rule       string `json:"rule"`
+	Severity   string `json:"severity"`
+	Confidence string `json:"confidence"`
+	Action     string `json:"action"`
+	Start      int    `json:"start"`
+	End        int    `json:"end"`
+	Text       string `json:"text"`
+}
+
+type AnalyzeRequest struct {
+	Content   string `json:"content"`
+	SourceApp string `json:"source_app,omitempty"`
+}
+
+// AnalyzeResponse explains what storing the content would do. Outcome is
+// ignored, blocked, redacted or stored; Stored is set when the content
+// would be changed.
+type AnalyzeResponse struct {
+	Outcome     string              `json:"outcome"`
+	IgnoredBy   string              `json:"ignored_by,omitempty"`
+	Type        string              `json:"type,omitempty"`
+	Language    string              `json:"language,omitempty"`
+	Domain      string              `json:"domain,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Sensitive   bool                `json:"sensitive"`
+	Reason      string              `json:"reason,omitempty"`
+	Severity    string              `json:"severity,omitempty"`
+	Confidence  string              `json:"confidence,omitempty"`
+	TTL         string              `json:"ttl,omitempty"`
+	Findings    []FindingResponse   `json:"findings"`
+	Matches     []MatchResponse     `json:"matches"`
+	Stored      string              `json:"stored,omitempty"`
+	Redactions  []RedactionResponse `json:"redactions,omitempty"`
+	OriginalURL string              `json:"original_url,omitempty"`
+}
+
+type FindingResponse struct {
+	Analyzer string  `json:"analyzer"`
+	Kind     string  `json:"kind"`
+	Value    string  `json:"value"`
+	Score    float64 `json:"score"`
+}
+
+// VaultEntryResponse never includes the content, except when a single
+// entry is requested for copying.
+type VaultEntryResponse struct {
+	Id               string    `json:"id"`
+	Preview          string    `json:"preview"`
+	Length           int       `json:"length"`
+	Type             string    `json:"type"`
+	Reason           string    `json:"reason"`
+	Severity         string    `json:"severity,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	ExpiresInSeconds int64     `json:"expires_in_seconds"`
+	Content          string    `json:"content,omitempty"`
+}
+
+type VaultResponse struct {
+	Entries []VaultEntryResponse `json:"entries"`
+	Count   int                  `json:"count"`
+}
+
+type TransformRequest struct {
+	Transforms []string `json:"transforms"`
+	Store      bool     `json:"store,omitempty"`
+}
+
+// TransformResponse holds the transformed content. Entry is set when the
+// result was stored.
+type TransformResponse struct {
+	Result     string         `json:"result"`
+	Transforms []string       `json:"transforms"`
+	Entry      *EntryResponse `json:"entry,omitempty"`
+}
+
+type TransformInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type TransformsResponse struct {
+	Transforms []TransformInfo `json:"transforms"`
 }
 
 type ErrorResponse struct {
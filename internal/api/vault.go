@@ -0,0 +1,95 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// GET /api/v1/vault
+func (h *Handler) ListVault(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.service.ListVault(r.Context())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	resp := VaultResponse{
+		Entries: make([]VaultEntryResponse, 0, len(entries)),
+		Count:   len(entries),
+	}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, newVaultEntryResponse(entry))
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GET /api/v1/vault/{id}
+func (h *Handler) GetVaultEntry(w http.ResponseWriter, r *http.Request) {
+	entry, content, err := h.service.RevealVaultEntry(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	resp := newVaultEntryResponse(entry)
+	resp.Content = content
+
+	w.Header().Set("Cache-Control", "no-store")
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// POST /api/v1/vault/{id}/copy
+func (h *Handler) CopyVaultEntry(w http.ResponseWriter, r *http.Request) {
+	entry, err := h.service.CopyVaultEntry(r.Context(), chi.URLParam(r, "id"), r.URL.Query().Get("selection"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newVaultEntryResponse(entry))
+}
+
+// DELETE /api/v1/vault/{id}
+func (h *Handler) DeleteVaultEntry(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteVaultEntry(r.Context(), chi.URLParam(r, "id")); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, SuccessResponse{
+		Message: "Vault entry deleted successfully",
+	})
+}
+
+// DELETE /api/v1/vault
+func (h *Handler) PurgeVault(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.service.ListVault(r.Context()); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	purged := h.service.PurgeVault()
+
+	respondJSON(w, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Vault cleared, %d entries removed", purged),
+	})
+}
+
+func newVaultEntryResponse(entry domain.VaultEntry) VaultEntryResponse {
+	return VaultEntryResponse{
+		Id:               entry.Id,
+		Preview:          entry.Preview,
+		Length:           entry.Length,
+		Type:             string(entry.Type),
+		Reason:           entry.Reason,
+		Severity:         string(entry.Severity),
+		CreatedAt:        entry.CreatedAt,
+		ExpiresAt:        entry.ExpiresAt,
+		ExpiresInSeconds: int64(max(time.Until(entry.ExpiresAt).Round(time.Second), 0) / time.Second),
+	}
+}
@@ -0,0 +1,90 @@
+// Package capture cleans up captures between the monitor and the service:
+// it normalizes content and, if asked to, truncates captures over the
+// ignore rules' maximum length instead of letting them be ignored.
+package capture
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// ReasonEmpty is reported by Apply for a capture with nothing left after
+// trimming.
+const ReasonEmpty = "empty"
+
+// What to do with a capture longer than the ignore rules' maximum length.
+const (
+	OverflowReject   = "reject"
+	OverflowTruncate = "truncate"
+)
+
+type Filter struct {
+	truncateAt   int
+	trimNewline  bool
+	normalizeNFC bool
+}
+
+// New returns a filter for cfg. maxLength is the ignore rules' maximum
+// length: over it a capture is truncated when cfg.Overflow is truncate, and
+// otherwise left for the ignore rules to reject.
+func New(cfg config.CaptureConfig, maxLength int) (*Filter, error) {
+	if maxLength < 0 {
+		return nil, fmt.Errorf("max length cannot be negative")
+	}
+
+	f := &Filter{
+		trimNewline:  cfg.TrimTrailingNewline,
+		normalizeNFC: cfg.NormalizeNFC,
+	}
+
+	switch cfg.Overflow {
+	case "", OverflowReject:
+	case OverflowTruncate:
+		f.truncateAt = maxLength
+	default:
+		return nil, fmt.Errorf("unknown capture overflow action %q (want %s or %s)", cfg.Overflow, OverflowReject, OverflowTruncate)
+	}
+
+	return f, nil
+}
+
+// Apply normalizes entry in place and returns why it should be dropped, or
+// "" to process it. The length is counted in characters after normalizing,
+// as the ignore rules count it.
+func (f *Filter) Apply(entry *domain.ClipboardEntry) string {
+	content := entry.Content
+
+	if f.normalizeNFC {
+		content = norm.NFC.String(content)
+	}
+	if f.trimNewline {
+		content = strings.TrimRight(content, "\r\n")
+	}
+	if content == "" {
+		return ReasonEmpty
+	}
+
+	if f.truncateAt > 0 && utf8.RuneCountInString(content) > f.truncateAt {
+		content = truncate(content, f.truncateAt)
+	}
+
+	entry.Content = content
+	return ""
+}
+
+// truncate cuts s after n characters.
+func truncate(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
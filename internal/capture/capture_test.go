@@ -0,0 +1,89 @@
+package capture
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+func TestApply(t *testing.T) {
+	truncate := config.CaptureConfig{Overflow: OverflowTruncate}
+
+	tests := []struct {
+		name        string
+		cfg         config.CaptureConfig
+		maxLength   int
+		content     string
+		wantReason  string
+		wantContent string
+	}{
+		{name: "no options", content: "hello\n", wantContent: "hello\n"},
+		{name: "trailing newline", cfg: config.CaptureConfig{TrimTrailingNewline: true}, content: "hello\r\n\n", wantContent: "hello"},
+		{name: "inner newlines kept", cfg: config.CaptureConfig{TrimTrailingNewline: true}, content: "a\nb\n", wantContent: "a\nb"},
+		{name: "trailing spaces kept", cfg: config.CaptureConfig{TrimTrailingNewline: true}, content: "a \n", wantContent: "a "},
+		{name: "only newlines", cfg: config.CaptureConfig{TrimTrailingNewline: true}, content: "\n\n", wantReason: ReasonEmpty},
+		{name: "NFC", cfg: config.CaptureConfig{NormalizeNFC: true}, content: "cafe\u0301", wantContent: "caf\u00e9"},
+		{name: "NFC off", content: "cafe\u0301", wantContent: "cafe\u0301"},
+		{name: "truncated", cfg: truncate, maxLength: 5, content: "hello world", wantContent: "hello"},
+		{name: "truncation counts characters", cfg: truncate, maxLength: 3, content: "héllo", wantContent: "hél"},
+		{name: "at the limit", cfg: truncate, maxLength: 5, content: "hello", wantContent: "hello"},
+		{name: "truncated after trimming", cfg: config.CaptureConfig{Overflow: OverflowTruncate, TrimTrailingNewline: true}, maxLength: 5, content: "hello\n", wantContent: "hello"},
+		{name: "no limit", cfg: truncate, content: "hello world", wantContent: "hello world"},
+		{name: "left to the ignore rules by default", maxLength: 5, content: "hello world", wantContent: "hello world"},
+		{name: "left to the ignore rules on reject", cfg: config.CaptureConfig{Overflow: OverflowReject}, maxLength: 5, content: "hello world", wantContent: "hello world"},
+		{name: "empty", content: "", wantReason: ReasonEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := New(tt.cfg, tt.maxLength)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			entry := &domain.ClipboardEntry{Content: tt.content}
+			if reason := filter.Apply(entry); reason != tt.wantReason {
+				t.Fatalf("Apply(%q) = %q, want %q", tt.content, reason, tt.wantReason)
+			}
+			if tt.wantReason == "" && entry.Content != tt.wantContent {
+				t.Errorf("Apply(%q) content = %q, want %q", tt.content, entry.Content, tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestApply_LargePaste(t *testing.T) {
+	filter, err := New(config.CaptureConfig{Overflow: OverflowTruncate}, 1000)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	entry := &domain.ClipboardEntry{Content: strings.Repeat("ab", 1<<20)}
+	if reason := filter.Apply(entry); reason != "" {
+		t.Fatalf("Apply() = %q, want the entry truncated", reason)
+	}
+	if len(entry.Content) != 1000 {
+		t.Errorf("Apply() kept %d bytes, want 1000", len(entry.Content))
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.CaptureConfig
+		maxLength int
+	}{
+		{name: "negative max length", maxLength: -1},
+		{name: "unknown overflow", cfg: config.CaptureConfig{Overflow: "drop"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.cfg, tt.maxLength); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
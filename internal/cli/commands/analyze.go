@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/geodask/clipboard-manager/internal/client"
+)
+
+type AnalyzeCommand struct{}
+
+func (c *AnalyzeCommand) Name() string {
+	return "analyze"
+}
+
+func (c *AnalyzeCommand) Description() string {
+	return "Show what the daemon would do with some text, without storing it"
+}
+
+func (c *AnalyzeCommand) Usage() string {
+	return "analyze [--json] [text]"
+}
+
+func (c *AnalyzeCommand) Execute(ctx context.Context, client *client.Client, args []string) error {
+	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	asJSON := fs.Bool("json", false, "Print raw JSON")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m", err, c.Usage())
+	}
+
+	content := strings.Join(fs.Args(), " ")
+	if content == "" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("reading stdin: %w", err)
+		}
+		content = string(data)
+	}
+	if content == "" {
+		return fmt.Errorf("Missing required argument: \033[1mtext\033[0m\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m\n\n\033[1mExample:\033[0m\n  \033[2m$\033[0m clipctl analyze 'mail jane@example.com'\n  \033[2m$\033[0m pbpaste | clipctl analyze", c.Usage())
+	}
+
+	result, err := client.Analyze(ctx, content)
+	if err != nil {
+		return fmt.Errorf("analyzing content: %w", err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	printAnalysis(content, result)
+	return nil
+}
+
+func printAnalysis(content string, result *client.AnalyzeResult) {
+	fmt.Println("\033[1m┌─ Analysis\033[0m")
+	switch result.Outcome {
+	case "ignored":
+		fmt.Printf("\033[1m│\033[0m \033[36mOutcome:\033[0m    \033[2mignored\033[0m by \033[1m%s\033[0m\n", result.IgnoredBy)
+		fmt.Printf("\033[1m└─ Content:\033[0m\n\n%s\n", content)
+		return
+	case "blocked":
+		fmt.Printf("\033[1m│\033[0m \033[36mOutcome:\033[0m    \033[31mblocked\033[0m by \033[1m%s\033[0m (%s severity, %s confidence)\n", result.Reason, result.Severity, result.Confidence)
+	case "redacted":
+		fmt.Printf("\033[1m│\033[0m \033[36mOutcome:\033[0m    \033[33mstored with %d redaction(s)\033[0m\n", len(result.Redactions))
+	default:
+		fmt.Printf("\033[1m│\033[0m \033[36mOutcome:\033[0m    \033[32mstored\033[0m\n")
+	}
+	if result.TTL != "" {
+		fmt.Printf("\033[1m│\033[0m \033[36mExpires:\033[0m    after %s (%s)\n", result.TTL, result.Reason)
+	}
+	fmt.Printf("\033[1m│\033[0m \033[36mType:\033[0m       %s\n", result.Type)
+	if result.Language != "" {
+		fmt.Printf("\033[1m│\033[0m \033[36mLanguage:\033[0m   %s\n", result.Language)
+	}
+	if result.Domain != "" {
+		fmt.Printf("\033[1m│\033[0m \033[36mDomain:\033[0m     %s\n", result.Domain)
+	}
+	if result.OriginalURL != "" {
+		fmt.Printf("\033[1m│\033[0m \033[36mCleaned:\033[0m    %s\n", result.Stored)
+	}
+	if len(result.Tags) > 0 {
+		fmt.Printf("\033[1m│\033[0m \033[36mTags:\033[0m       %s\n", strings.Join(result.Tags, ", "))
+	}
+
+	if len(result.Findings) > 0 {
+		fmt.Println("\033[1m├─ Findings\033[0m")
+		for _, finding := range result.Findings {
+			fmt.Printf("\033[1m│\033[0m   \033[2m%-10s\033[0m %-9s \033[36m%-14s\033[0m \033[2m%.2f\033[0m\n", finding.Analyzer, finding.Kind, finding.Value, finding.Score)
+		}
+	}
+
+	if len(result.Matches) > 0 {
+		fmt.Println("\033[1m├─ Matches\033[0m")
+		for i, match := range result.Matches {
+			fmt.Printf("\033[1m│\033[0m   \033[2m[%d]\033[0m %s%-22s\033[0m %-9s %-8s %-6s \033[2m%s [%d:%d]\033[0m\n", i+1, actionColor(match.Action), match.Rule, match.Action, match.Severity, match.Confidence, match.Analyzer, match.Start, match.End)
+		}
+	}
+
+	fmt.Printf("\033[1m└─ Content:\033[0m\n\n%s\n", annotateMatches(content, result.Matches))
+}
+
+// annotateMatches colours each matched span by its action and numbers it
+// to match the list above. Spans overlapping an earlier one are listed but
+// not marked.
+func annotateMatches(content string, matches []client.RuleMatch) string {
+	order := make([]int, len(matches))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return matches[order[a]].Start < matches[order[b]].Start
+	})
+
+	var b strings.Builder
+	last := 0
+	for _, i := range order {
+		match := matches[i]
+		if match.Start < last || match.End > len(content) {
+			continue
+		}
+		b.WriteString(content[last:match.Start])
+		b.WriteString("\033[7m" + actionColor(match.Action) + content[match.Start:match.End] + "\033[0m")
+		fmt.Fprintf(&b, "\033[2m[%d]\033[0m", i+1)
+		last = match.End
+	}
+	b.WriteString(content[last:])
+	return b.String()
+}
+
+func actionColor(action string) string {
+	switch action {
+	case "block":
+		return "\033[31m"
+	case "redact":
+		return "\033[33m"
+	case "short_ttl":
+		return "\033[35m"
+	case "allow":
+		return "\033[32m"
+	}
+	return ""
+}
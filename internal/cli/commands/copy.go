@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/geodask/clipboard-manager/internal/client"
+)
+
+type CopyCommand struct{}
+
+func (c *CopyCommand) Name() string {
+	return "copy"
+}
+
+func (c *CopyCommand) Description() string {
+	return "Copy a history entry back to the clipboard"
+}
+
+func (c *CopyCommand) Usage() string {
+	return "copy [--selection clipboard|primary|secondary] <id>"
+}
+
+func (c *CopyCommand) Execute(ctx context.Context, client *client.Client, args []string) error {
+	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	selection := fs.String("selection", "", "Selection to write to")
+	primary := fs.Bool("primary", false, "Shorthand for --selection primary")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m", err, c.Usage())
+	}
+	if *primary {
+		*selection = "primary"
+	}
+
+	if fs.NArg() < 1 {
+		return fmt.Errorf("Missing required argument: \033[1mid\033[0m\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m\n\n\033[1mExample:\033[0m\n  \033[2m$\033[0m clipctl copy abc123\n  \033[2m$\033[0m clipctl copy --primary abc123\n\n\033[2mTip: Use 'clipctl list' to see available entry IDs\033[0m", c.Usage())
+	}
+
+	id := fs.Arg(0)
+	entry, err := client.CopyEntry(ctx, id, *selection)
+	if err != nil {
+		return fmt.Errorf("copying entry: %w", err)
+	}
+
+	target := "the clipboard"
+	if *selection != "" {
+		target = "the " + *selection + " selection"
+	}
+	fmt.Printf("Copied entry \033[1m%s\033[0m to %s \033[2m(used %d time(s))\033[0m\n", entry.Id, target, entry.UseCount)
+	return nil
+}
@@ -3,6 +3,8 @@ package commands
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/geodask/clipboard-manager/internal/client"
 )
@@ -35,8 +37,59 @@ func (c *GetCommand) Execute(ctx context.Context, client *client.Client, args []
 	fmt.Printf("\033[1m┌─ Entry Details\033[0m\n")
 	fmt.Printf("\033[1m│\033[0m \033[36mID:\033[0m         %s\n", entry.Id)
 	fmt.Printf("\033[1m│\033[0m \033[36mTimestamp:\033[0m  %s\n", entry.Timestamp.Format("2006-01-02 15:04:05"))
+	if entry.ExpiresAt != nil {
+		fmt.Printf("\033[1m│\033[0m \033[36mExpires:\033[0m    %s \033[2m(in %s)\033[0m\n", entry.ExpiresAt.Format("2006-01-02 15:04:05"), time.Until(*entry.ExpiresAt).Round(time.Second))
+	}
+	if entry.Selection != "" && entry.Selection != "clipboard" {
+		fmt.Printf("\033[1m│\033[0m \033[36mSelection:\033[0m  %s\n", entry.Selection)
+	}
+	if entry.Source != "" && entry.Source != "clipboard" {
+		fmt.Printf("\033[1m│\033[0m \033[36mSource:\033[0m     %s\n", entry.Source)
+	}
+	if entry.LastUsedAt != nil {
+		fmt.Printf("\033[1m│\033[0m \033[36mCopied:\033[0m     %d time(s), last %s\n", entry.UseCount, entry.LastUsedAt.Format("2006-01-02 15:04:05"))
+	}
+	if entry.Language != "" {
+		fmt.Printf("\033[1m│\033[0m \033[36mLanguage:\033[0m   %s\n", entry.Language)
+	}
+	if entry.Domain != "" {
+		fmt.Printf("\033[1m│\033[0m \033[36mDomain:\033[0m     %s\n", entry.Domain)
+	}
+	if entry.OriginalURL != "" {
+		fmt.Printf("\033[1m│\033[0m \033[36mOriginal:\033[0m   \033[2m%s\033[0m\n", entry.OriginalURL)
+	}
+	if len(entry.Redactions) > 0 {
+		fmt.Printf("\033[1m│\033[0m \033[36mRedacted:\033[0m   %d span(s)\n", len(entry.Redactions))
+		for _, redaction := range entry.Redactions {
+			line := strings.Count(entry.Content[:redaction.Start], "\n") + 1
+			fmt.Printf("\033[1m│\033[0m   \033[33m%-22s\033[0m \033[2mline %d\033[0m\n", redaction.Rule, line)
+		}
+	}
 	fmt.Printf("\033[1m└─ Content:\033[0m\n")
-	fmt.Printf("\n%s\n", entry.Content)
+	// Redaction offsets refer to the raw content, so syntax colouring is
+	// only applied to entries without redactions.
+	if len(entry.Redactions) > 0 {
+		fmt.Printf("\n%s\n", highlightRedactions(entry.Content, entry.Redactions))
+	} else {
+		fmt.Printf("\n%s\n", highlightCode(entry.Content, entry.Language))
+	}
 
 	return nil
 }
+
+// highlightRedactions shows redaction placeholders in reverse video so they
+// stand out from the surrounding content.
+func highlightRedactions(content string, redactions []client.Redaction) string {
+	var b strings.Builder
+	last := 0
+	for _, redaction := range redactions {
+		if redaction.Start < last || redaction.End > len(content) {
+			continue
+		}
+		b.WriteString(content[last:redaction.Start])
+		b.WriteString("\033[7;33m" + content[redaction.Start:redaction.End] + "\033[0m")
+		last = redaction.End
+	}
+	b.WriteString(content[last:])
+	return b.String()
+}
@@ -0,0 +1,278 @@
+package commands
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	colorKeyword = "\033[35m"
+	colorString  = "\033[32m"
+	colorNumber  = "\033[36m"
+	colorComment = "\033[2m"
+	colorType    = "\033[33m"
+	colorReset   = "\033[0m"
+)
+
+// syntax describes just enough of a language to colour it: comments,
+// strings, keywords and a few well-known type names.
+type syntax struct {
+	lineComments []string
+	blockComment [2]string
+	quotes       string
+	keywords     map[string]bool
+	types        map[string]bool
+	ignoreCase   bool
+}
+
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		set[w] = true
+	}
+	return set
+}
+
+var javaScriptKeywords = "async await break case catch class const continue default delete do else export extends finally for from function if import in instanceof let new of return static super switch this throw try typeof var void while yield null undefined true false"
+
+var syntaxes = map[string]syntax{
+	"go": {
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'`",
+		keywords:     words("break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var nil true false iota"),
+		types:        words("bool byte error float32 float64 int int8 int16 int32 int64 rune string uint uint8 uint16 uint32 uint64 any"),
+	},
+	"python": {
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+		keywords:     words("and as assert async await break class continue def del elif else except finally for from global if import in is lambda nonlocal not or pass raise return try while with yield None True False self"),
+		types:        words("int float str bool list dict set tuple bytes object"),
+	},
+	"javascript": {
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'`",
+		keywords:     words(javaScriptKeywords),
+	},
+	"typescript": {
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'`",
+		keywords:     words(javaScriptKeywords + " interface type enum implements private public protected readonly declare namespace as"),
+		types:        words("string number boolean any unknown never void object"),
+	},
+	"rust": {
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"",
+		keywords:     words("as async await break const continue crate dyn else enum extern fn for if impl in let loop match mod move mut pub ref return self Self static struct super trait type unsafe use where while true false"),
+		types:        words("i8 i16 i32 i64 i128 isize u8 u16 u32 u64 u128 usize f32 f64 bool char str String Vec Option Result Box Some None Ok Err"),
+	},
+	"java": {
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'",
+		keywords:     words("abstract assert break case catch class continue default do else enum extends final finally for if implements import instanceof interface new package private protected public return static super switch synchronized this throw throws try void volatile while null true false"),
+		types:        words("boolean byte char double float int long short String Object Integer List Map"),
+	},
+	"sql": {
+		lineComments: []string{"--"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "'\"",
+		keywords:     words("select from where and or not in is null as join left right inner outer on group by order having limit offset insert into values update set delete create table index view drop alter add primary key foreign references distinct union all case when then else end asc desc like between exists with"),
+		types:        words("int integer bigint text varchar char boolean date timestamp real float numeric blob"),
+		ignoreCase:   true,
+	},
+	"bash": {
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+		keywords:     words("if then else elif fi for while until do done case esac in function return local export readonly source echo exit set unset shift"),
+	},
+	"yaml": {
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+		keywords:     words("true false null yes no on off"),
+	},
+	"css": {
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'",
+		keywords:     words("important inherit initial none auto"),
+	},
+}
+
+var (
+	yamlKeyPattern  = regexp.MustCompile(`(?m)^(\s*-?\s*)([\w.-]+)(:)`)
+	cssPropPattern  = regexp.MustCompile(`(?m)^(\s*)([a-z-]+)(\s*:)`)
+	htmlTagPattern  = regexp.MustCompile(`</?[A-Za-z][\w-]*|/?>`)
+	htmlAttrPattern = regexp.MustCompile(`\s([\w-]+)=("[^"]*"|'[^']*')`)
+	htmlComment     = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+// highlightCode colours content for a terminal according to its detected
+// language. Unknown languages are returned unchanged.
+func highlightCode(content, lang string) string {
+	switch lang {
+	case "html":
+		return highlightHTML(content)
+	case "yaml":
+		return highlightKeys(tokenize(content, syntaxes[lang]), yamlKeyPattern)
+	case "css":
+		return highlightKeys(tokenize(content, syntaxes[lang]), cssPropPattern)
+	}
+
+	s, ok := syntaxes[lang]
+	if !ok {
+		return content
+	}
+	return tokenize(content, s)
+}
+
+// tokenize walks content once, colouring comments, strings, numbers,
+// keywords and types. It does not try to be a parser; a stray quote
+// colours the rest of its line at worst.
+func tokenize(content string, s syntax) string {
+	var b strings.Builder
+	for i := 0; i < len(content); {
+		rest := content[i:]
+
+		if s.blockComment[0] != "" && strings.HasPrefix(rest, s.blockComment[0]) {
+			end := strings.Index(rest[len(s.blockComment[0]):], s.blockComment[1])
+			n := len(rest)
+			if end >= 0 {
+				n = len(s.blockComment[0]) + end + len(s.blockComment[1])
+			}
+			b.WriteString(colorComment + rest[:n] + colorReset)
+			i += n
+			continue
+		}
+
+		if prefix := lineCommentPrefix(rest, s.lineComments); prefix != "" && startsComment(content, i, prefix) {
+			n := strings.IndexByte(rest, '\n')
+			if n < 0 {
+				n = len(rest)
+			}
+			b.WriteString(colorComment + rest[:n] + colorReset)
+			i += n
+			continue
+		}
+
+		c := content[i]
+		switch {
+		case strings.IndexByte(s.quotes, c) >= 0:
+			n := stringLength(rest, c)
+			b.WriteString(colorString + rest[:n] + colorReset)
+			i += n
+		case isDigit(c) && (i == 0 || !isWordByte(content[i-1])):
+			n := 1
+			for n < len(rest) && (isWordByte(rest[n]) || rest[n] == '.') {
+				n++
+			}
+			b.WriteString(colorNumber + rest[:n] + colorReset)
+			i += n
+		case isWordByte(c):
+			n := 1
+			for n < len(rest) && isWordByte(rest[n]) {
+				n++
+			}
+			word := rest[:n]
+			key := word
+			if s.ignoreCase {
+				key = strings.ToLower(word)
+			}
+			switch {
+			case s.keywords[key]:
+				b.WriteString(colorKeyword + word + colorReset)
+			case s.types[key]:
+				b.WriteString(colorType + word + colorReset)
+			default:
+				b.WriteString(word)
+			}
+			i += n
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+func lineCommentPrefix(rest string, prefixes []string) string {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(rest, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// startsComment keeps "#" inside words such as "C#" or "$#" from being
+// read as a comment in shell-like languages.
+func startsComment(content string, i int, prefix string) bool {
+	if prefix != "#" || i == 0 {
+		return true
+	}
+	return unicode.IsSpace(rune(content[i-1]))
+}
+
+// stringLength returns the length of the quoted string at the start of s,
+// honouring backslash escapes. Unterminated strings end at the newline,
+// except for backtick strings which may span lines.
+func stringLength(s string, quote byte) int {
+	for n := 1; n < len(s); n++ {
+		switch s[n] {
+		case '\\':
+			n++
+		case quote:
+			return n + 1
+		case '\n':
+			if quote != '`' {
+				return n
+			}
+		}
+	}
+	return len(s)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// highlightKeys colours mapping keys (YAML) or property names (CSS) in
+// already tokenized content. Keys never contain escape codes, so matching
+// after tokenizing is safe.
+func highlightKeys(content string, pattern *regexp.Regexp) string {
+	return pattern.ReplaceAllString(content, "${1}"+colorType+"${2}"+colorReset+"${3}")
+}
+
+func highlightHTML(content string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range htmlComment.FindAllStringIndex(content, -1) {
+		b.WriteString(highlightTags(content[last:loc[0]]))
+		b.WriteString(colorComment + content[loc[0]:loc[1]] + colorReset)
+		last = loc[1]
+	}
+	b.WriteString(highlightTags(content[last:]))
+	return b.String()
+}
+
+func highlightTags(content string) string {
+	content = htmlAttrPattern.ReplaceAllString(content, " "+colorType+"${1}"+colorReset+"="+colorString+"${2}"+colorReset)
+	return htmlTagPattern.ReplaceAllStringFunc(content, func(tag string) string {
+		return colorKeyword + tag + colorReset
+	})
+}
+
+// languageTag labels list rows with the detected language.
+func languageTag(lang string) string {
+	if lang == "" {
+		return ""
+	}
+	return " " + colorKeyword + "[" + lang + "]" + colorReset
+}
@@ -2,7 +2,9 @@ package commands
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"io"
 	"strconv"
 
 	"github.com/geodask/clipboard-manager/internal/client"
@@ -19,7 +21,7 @@ func (c *ListCommand) Description() string {
 }
 
 func (c *ListCommand) Usage() string {
-	return "list [n]"
+	return "list [n] [--lang go] [--domain github.com]"
 }
 
 func (c *ListCommand) Execute(ctx context.Context, client *client.Client, args []string) error {
@@ -27,10 +29,20 @@ func (c *ListCommand) Execute(ctx context.Context, client *client.Client, args [
 	if len(args) > 0 {
 		if num, err := strconv.Atoi(args[0]); err == nil {
 			n = num
+			args = args[1:]
 		}
 	}
 
-	entries, err := client.GetHistory(ctx, n)
+	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	lang := fs.String("lang", "", "Only show code in this language")
+	domain := fs.String("domain", "", "Only show URLs on this domain")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m", err, c.Usage())
+	}
+
+	entries, err := client.GetHistory(ctx, n, historyFilter(*lang, *domain))
 	if err != nil {
 		return fmt.Errorf("retrieving history: %w", err)
 	}
@@ -43,15 +55,38 @@ func (c *ListCommand) Execute(ctx context.Context, client *client.Client, args [
 	fmt.Printf("\033[1mLast %d clipboard entries:\033[0m\n\n", len(entries))
 	for i := len(entries) - 1; i >= 0; i-- {
 		entry := entries[i]
-		fmt.Printf("\033[2m[\033[0m\033[36m%s\033[0m\033[2m]\033[0m \033[2m(ID: %s)\033[0m\n%s\n\033[2m───────────────────────────────────────────────────────────────\033[0m\n",
+		fmt.Printf("\033[2m[\033[0m\033[36m%s\033[0m\033[2m]\033[0m \033[2m(ID: %s)\033[0m%s\n%s\n\033[2m───────────────────────────────────────────────────────────────\033[0m\n",
 			entry.Timestamp.Format("2006-01-02 15:04:05"),
 			entry.Id,
+			sourceTag(entry.Source)+selectionTag(entry.Selection)+languageTag(entry.Language),
 			truncate(entry.Content, 100))
 	}
 
 	return nil
 }
 
+// selectionTag marks entries captured from a selection other than the
+// clipboard.
+func selectionTag(selection string) string {
+	if selection == "" || selection == "clipboard" {
+		return ""
+	}
+	return " \033[35m(" + selection + ")\033[0m"
+}
+
+func sourceTag(source string) string {
+	if source == "" || source == "clipboard" {
+		return ""
+	}
+	return " \033[33m(" + source + ")\033[0m"
+}
+
+// historyFilter builds the filter outside Execute, where the client
+// parameter shadows the package.
+func historyFilter(lang, domain string) client.HistoryFilter {
+	return client.HistoryFilter{Language: lang, Domain: domain}
+}
+
 func truncate(s string, max int) string {
 	if len(s) <= max {
 		return s
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/client"
+)
+
+type MaintenanceCommand struct{}
+
+func (c *MaintenanceCommand) Name() string {
+	return "maintenance"
+}
+
+func (c *MaintenanceCommand) Description() string {
+	return "Run or inspect database maintenance"
+}
+
+func (c *MaintenanceCommand) Usage() string {
+	return "maintenance [run|status]"
+}
+
+func (c *MaintenanceCommand) Execute(ctx context.Context, client *client.Client, args []string) error {
+	action := "status"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	fetch, verb := client.GetMaintenance, "retrieving maintenance status"
+	switch action {
+	case "run":
+		fetch, verb = client.RunMaintenance, "running maintenance"
+	case "status":
+	default:
+		return fmt.Errorf("Unknown action: \033[1m%s\033[0m\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m", action, c.Usage())
+	}
+
+	report, err := fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", verb, err)
+	}
+
+	integrity := "\033[32mok\033[0m"
+	if !report.IntegrityOK {
+		integrity = "\033[31mFAILED\033[0m"
+	}
+
+	fmt.Println("\033[1m┌─ Database Maintenance\033[0m")
+	fmt.Printf("\033[1m│\033[0m \033[36mStarted:\033[0m    %s\n", report.StartedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("\033[1m│\033[0m \033[36mDuration:\033[0m   %s\n", time.Duration(report.DurationMs)*time.Millisecond)
+	fmt.Printf("\033[1m│\033[0m \033[36mIntegrity:\033[0m  %s\n", integrity)
+	for _, problem := range report.IntegrityErrors {
+		fmt.Printf("\033[1m│\033[0m   \033[31m%s\033[0m\n", problem)
+	}
+	if report.FullVacuum {
+		fmt.Printf("\033[1m│\033[0m \033[36mVacuum:\033[0m     full (enabled incremental vacuum)\n")
+	}
+	fmt.Printf("\033[1m│\033[0m \033[36mSize:\033[0m       %s → %s\n", formatBytes(report.SizeBefore), formatBytes(report.SizeAfter))
+	fmt.Printf("\033[1m└─\033[0m \033[36mReclaimed:\033[0m  %s\n", formatBytes(report.ReclaimedBytes))
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/client"
+)
+
+type PauseCommand struct{}
+
+func (c *PauseCommand) Name() string {
+	return "pause"
+}
+
+func (c *PauseCommand) Description() string {
+	return "Stop recording clipboard changes, for a while or until resumed"
+}
+
+func (c *PauseCommand) Usage() string {
+	return "pause [duration]"
+}
+
+func (c *PauseCommand) Execute(ctx context.Context, client *client.Client, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("Too many arguments\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m\n\n\033[1mExample:\033[0m\n  \033[2m$\033[0m clipctl pause 10m\n  \033[2m$\033[0m clipctl pause", c.Usage())
+	}
+
+	duration := ""
+	if len(args) == 1 {
+		duration = args[0]
+	}
+
+	state, err := client.Pause(ctx, duration)
+	if err != nil {
+		return fmt.Errorf("pausing capture: %w", err)
+	}
+
+	fmt.Printf("\033[33m⏸\033[0m Capture paused %s\n", pausedUntil(state.PausedUntil))
+	return nil
+}
+
+type ResumeCommand struct{}
+
+func (c *ResumeCommand) Name() string {
+	return "resume"
+}
+
+func (c *ResumeCommand) Description() string {
+	return "Resume recording clipboard changes"
+}
+
+func (c *ResumeCommand) Usage() string {
+	return "resume"
+}
+
+func (c *ResumeCommand) Execute(ctx context.Context, client *client.Client, args []string) error {
+	if _, err := client.Resume(ctx); err != nil {
+		return fmt.Errorf("resuming capture: %w", err)
+	}
+
+	fmt.Println("\033[32m▶\033[0m Capture resumed")
+	return nil
+}
+
+func pausedUntil(until *time.Time) string {
+	if until == nil {
+		return "until resumed"
+	}
+	return fmt.Sprintf("until %s \033[2m(%s)\033[0m", until.Local().Format("15:04:05"), time.Until(*until).Round(time.Second))
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/geodask/clipboard-manager/internal/client"
+)
+
+type ProfileCommand struct{}
+
+func (c *ProfileCommand) Name() string {
+	return "profile"
+}
+
+func (c *ProfileCommand) Description() string {
+	return "Manage clipboard profiles"
+}
+
+func (c *ProfileCommand) Usage() string {
+	return "profile list | use <name> | create <name> [--retention 30d] | delete <name>"
+}
+
+func (c *ProfileCommand) Execute(ctx context.Context, client *client.Client, args []string) error {
+	action := "list"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	if action == "list" {
+		return c.list(ctx, client)
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("Missing required argument: \033[1mname\033[0m\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m\n\n\033[1mExample:\033[0m\n  \033[2m$\033[0m clipctl profile create work --retention 7d\n  \033[2m$\033[0m clipctl profile use work", c.Usage())
+	}
+	name := args[1]
+
+	switch action {
+	case "use":
+		if _, err := client.ActivateProfile(ctx, name); err != nil {
+			return fmt.Errorf("activating profile: %w", err)
+		}
+		fmt.Printf("Now capturing into profile \033[1m%s\033[0m\n", name)
+
+	case "create":
+		fs := flag.NewFlagSet("profile create", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		retention := fs.String("retention", "", "Max age of entries in this profile (e.g. 72h, 30d)")
+		if err := fs.Parse(args[2:]); err != nil {
+			return fmt.Errorf("%v\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m", err, c.Usage())
+		}
+
+		if _, err := client.CreateProfile(ctx, name, *retention); err != nil {
+			return fmt.Errorf("creating profile: %w", err)
+		}
+		fmt.Printf("Profile \033[1m%s\033[0m created\n", name)
+
+	case "delete":
+		if err := client.DeleteProfile(ctx, name); err != nil {
+			return fmt.Errorf("deleting profile: %w", err)
+		}
+		fmt.Printf("Profile \033[1m%s\033[0m deleted successfully\n", name)
+
+	default:
+		return fmt.Errorf("Unknown action: \033[1m%s\033[0m\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m", action, c.Usage())
+	}
+
+	return nil
+}
+
+func (c *ProfileCommand) list(ctx context.Context, client *client.Client) error {
+	resp, err := client.ListProfiles(ctx)
+	if err != nil {
+		return fmt.Errorf("listing profiles: %w", err)
+	}
+
+	fmt.Println("\033[1mProfiles:\033[0m")
+	for _, profile := range resp.Profiles {
+		marker := " "
+		if profile.Active {
+			marker = "\033[32m*\033[0m"
+		}
+
+		retention := "default retention"
+		if profile.RetentionMaxAge != "" {
+			retention = "retention " + profile.RetentionMaxAge
+		}
+
+		fmt.Printf(" %s \033[36m%-16s\033[0m \033[2m%s, created %s\033[0m\n", marker, profile.Name, retention, profile.CreatedAt.Format("2006-01-02"))
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/geodask/clipboard-manager/internal/client"
+)
+
+type RulesCommand struct{}
+
+func (c *RulesCommand) Name() string {
+	return "rules"
+}
+
+func (c *RulesCommand) Description() string {
+	return "List, reload or test sensitive-content rules"
+}
+
+func (c *RulesCommand) Usage() string {
+	return "rules list | reload | test [text]"
+}
+
+func (c *RulesCommand) Execute(ctx context.Context, client *client.Client, args []string) error {
+	action := "list"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	switch action {
+	case "list":
+		resp, err := client.ListRules(ctx)
+		if err != nil {
+			return fmt.Errorf("listing rules: %w", err)
+		}
+		printRules(resp.Rules)
+
+	case "reload":
+		resp, err := client.ReloadRules(ctx)
+		if err != nil {
+			return fmt.Errorf("reloading rules: %w", err)
+		}
+		fmt.Printf("Reloaded \033[1m%d\033[0m rules\n", resp.Count)
+
+	case "test":
+		content := strings.Join(args[1:], " ")
+		if content == "" {
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("reading stdin: %w", err)
+			}
+			content = string(data)
+		}
+		if content == "" {
+			return fmt.Errorf("Missing required argument: \033[1mtext\033[0m\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m\n\n\033[1mExample:\033[0m\n  \033[2m$\033[0m clipctl rules test 'password=hunter2'\n  \033[2m$\033[0m pbpaste | clipctl rules test", c.Usage())
+		}
+
+		result, err := client.TestRules(ctx, content)
+		if err != nil {
+			return fmt.Errorf("testing rules: %w", err)
+		}
+		printRuleTest(content, result)
+
+	default:
+		return fmt.Errorf("Unknown action: \033[1m%s\033[0m\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m", action, c.Usage())
+	}
+
+	return nil
+}
+
+func printRules(rules []client.Rule) {
+	fmt.Println("\033[1mRules:\033[0m")
+	for _, rule := range rules {
+		matcher := rule.Pattern
+		switch {
+		case rule.Detector:
+			matcher = "detector"
+		case matcher == "":
+			matcher = "keywords: " + strings.Join(rule.Keywords, ", ")
+		}
+
+		origin := ""
+		if rule.Builtin && !rule.Detector {
+			origin = " \033[2m(built-in)\033[0m"
+		}
+
+		fmt.Printf("  \033[36m%-22s\033[0m %-8s %-6s \033[2m%s\033[0m%s\n", rule.Name, rule.Severity, rule.Action, matcher, origin)
+	}
+}
+
+func printRuleTest(content string, result *client.AnalyzeResult) {
+	switch {
+	case result.Outcome == "ignored":
+		fmt.Printf("\033[2mIgnored\033[0m by rule \033[1m%s\033[0m\n", result.IgnoredBy)
+		return
+	case result.Outcome == "blocked":
+		fmt.Printf("\033[31mBlocked\033[0m by rule \033[1m%s\033[0m (%s severity, %s confidence)\n", result.Reason, result.Severity, result.Confidence)
+	case result.Outcome == "redacted":
+		fmt.Println("\033[33mStored with redactions\033[0m")
+	case len(result.Matches) > 0:
+		fmt.Println("\033[32mStored unchanged\033[0m")
+	default:
+		fmt.Printf("\033[32mAllowed\033[0m as \033[1m%s\033[0m\n", result.Type)
+		return
+	}
+
+	fmt.Println()
+	for _, match := range result.Matches {
+		fmt.Printf("  \033[36m%-22s\033[0m %-8s %-6s %-6s \033[2m[%d:%d]\033[0m %q\n", match.Rule, match.Severity, match.Confidence, match.Action, match.Start, match.End, match.Text)
+	}
+}
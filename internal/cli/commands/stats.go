@@ -2,7 +2,14 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/geodask/clipboard-manager/internal/client"
 )
@@ -18,18 +25,138 @@ func (c *StatsCommand) Description() string {
 }
 
 func (c *StatsCommand) Usage() string {
-	return "stats"
+	return "stats [--json] [--by hour|day] [--window 7d]"
 }
 
 func (c *StatsCommand) Execute(ctx context.Context, client *client.Client, args []string) error {
-	stats, err := client.GetStats(ctx)
+	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	asJSON := fs.Bool("json", false, "Print raw JSON")
+	bucket := fs.String("by", "day", "Histogram bucket (hour or day)")
+	window := fs.String("window", "", "Histogram window (e.g. 24h, 7d)")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m", err, c.Usage())
+	}
+
+	stats, err := client.GetStats(ctx, *bucket, *window)
 	if err != nil {
 		return fmt.Errorf("Error getting stats: %v\n", err)
 	}
 
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+
 	fmt.Println("\033[1m┌─ Daemon Statistics\033[0m")
 	fmt.Printf("\033[1m│\033[0m \033[36mStatus:\033[0m         %s\n", stats.Status)
-	fmt.Printf("\033[1m└─\033[0m \033[36mTotal Entries:\033[0m %d\n", stats.TotalEntries)
+	if stats.Paused {
+		fmt.Printf("\033[1m│\033[0m \033[36mCapture:\033[0m        \033[33mpaused\033[0m %s\n", pausedUntil(stats.PausedUntil))
+	}
+	fmt.Printf("\033[1m│\033[0m \033[36mUptime:\033[0m         %s\n", time.Duration(stats.UptimeSeconds)*time.Second)
+	fmt.Printf("\033[1m│\033[0m \033[36mTotal Entries:\033[0m  %d\n", stats.TotalEntries)
+	fmt.Printf("\033[1m│\033[0m \033[36mTotal Size:\033[0m     %s \033[2m(avg %s)\033[0m\n", formatBytes(stats.TotalBytes), formatBytes(int64(stats.AverageBytes)))
+	fmt.Printf("\033[1m│\033[0m \033[36mDatabase Size:\033[0m  %s\n", formatBytes(stats.DatabaseBytes))
+	if stats.OldestEntry != nil && stats.NewestEntry != nil {
+		fmt.Printf("\033[1m│\033[0m \033[36mOldest Entry:\033[0m   %s\n", stats.OldestEntry.Format("2006-01-02 15:04:05"))
+		fmt.Printf("\033[1m│\033[0m \033[36mNewest Entry:\033[0m   %s\n", stats.NewestEntry.Format("2006-01-02 15:04:05"))
+	}
+	fmt.Printf("\033[1m│\033[0m \033[36mSensitive Skips:\033[0m %d\n", stats.SensitiveSkipped)
+	for _, reason := range sortedKeys(stats.SensitiveByReason) {
+		fmt.Printf("\033[1m│\033[0m   \033[2m%-20s\033[0m %d\n", reason, stats.SensitiveByReason[reason])
+	}
+	fmt.Printf("\033[1m│\033[0m \033[36mIgnored:\033[0m        %d\n", stats.IgnoredSkipped)
+	for _, rule := range sortedKeys(stats.IgnoredByRule) {
+		fmt.Printf("\033[1m│\033[0m   \033[2m%-20s\033[0m %d\n", rule, stats.IgnoredByRule[rule])
+	}
+
+	fmt.Println("\033[1m├─ By Type\033[0m")
+	for _, contentType := range sortedKeys(stats.CountsByType) {
+		fmt.Printf("\033[1m│\033[0m   \033[36m%-10s\033[0m %d\n", contentType, stats.CountsByType[contentType])
+	}
+
+	if len(stats.LargestEntries) > 0 {
+		fmt.Println("\033[1m├─ Largest Entries\033[0m")
+		for _, entry := range stats.LargestEntries {
+			fmt.Printf("\033[1m│\033[0m   \033[2m(ID: %s)\033[0m %-10s %s\n", entry.Id, entry.Type, formatBytes(int64(entry.Size)))
+		}
+	}
+
+	if len(stats.Monitor) > 0 {
+		fmt.Println("\033[1m├─ Monitor\033[0m")
+		for _, status := range stats.Monitor {
+			fmt.Printf("\033[1m│\033[0m   \033[36m%-10s\033[0m %s\n", status.Selection, monitorState(status))
+			if status.ErrorStreak > 0 {
+				fmt.Printf("\033[1m│\033[0m     \033[31m%d failed reads\033[0m \033[2m(last: %s)\033[0m\n", status.ErrorStreak, status.LastError)
+			}
+		}
+	}
+
+	counts := make([]int, len(stats.Activity.Buckets))
+	total := 0
+	for i, bucket := range stats.Activity.Buckets {
+		counts[i] = bucket.Count
+		total += bucket.Count
+	}
+
+	fmt.Printf("\033[1m└─ Activity\033[0m \033[2m(per %s, last %s)\033[0m\n", stats.Activity.Bucket, stats.Activity.Window)
+	fmt.Printf("   \033[36m%s\033[0m  %d entries\n", sparkline(counts), total)
 
 	return nil
 }
+
+func monitorState(status client.MonitorStatus) string {
+	if status.Mode == "events" {
+		return fmt.Sprintf("%s events", status.Backend)
+	}
+	interval := time.Duration(status.IntervalMs) * time.Millisecond
+	if status.Backend != "poll" {
+		return fmt.Sprintf("polling every %s \033[2m(%s events failed)\033[0m", interval, status.Backend)
+	}
+	return fmt.Sprintf("polling every %s", interval)
+}
+
+// sparkline renders counts as a row of block characters scaled to the maximum.
+func sparkline(counts []int) string {
+	const ticks = "▁▂▃▄▅▆▇█"
+	levels := []rune(ticks)
+
+	maxCount := 0
+	for _, n := range counts {
+		maxCount = max(maxCount, n)
+	}
+
+	var sb strings.Builder
+	for _, n := range counts {
+		if maxCount == 0 {
+			sb.WriteRune(levels[0])
+			continue
+		}
+		sb.WriteRune(levels[n*(len(levels)-1)/maxCount])
+	}
+	return sb.String()
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
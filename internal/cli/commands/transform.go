@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/geodask/clipboard-manager/internal/client"
+)
+
+type TransformCommand struct{}
+
+func (c *TransformCommand) Name() string {
+	return "transform"
+}
+
+func (c *TransformCommand) Description() string {
+	return "Apply transforms to an entry, optionally storing the result"
+}
+
+func (c *TransformCommand) Usage() string {
+	return "transform [--store] <id> <t1,t2,...> | --list"
+}
+
+func (c *TransformCommand) Execute(ctx context.Context, client *client.Client, args []string) error {
+	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	store := fs.Bool("store", false, "Store the result as a new entry")
+	list := fs.Bool("list", false, "List available transforms")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m", err, c.Usage())
+	}
+
+	if *list {
+		transforms, err := client.ListTransforms(ctx)
+		if err != nil {
+			return fmt.Errorf("listing transforms: %w", err)
+		}
+		for _, t := range transforms {
+			fmt.Printf("\033[36m%-14s\033[0m \033[2m%s\033[0m\n", t.Name, t.Description)
+		}
+		return nil
+	}
+
+	if fs.NArg() < 2 {
+		return fmt.Errorf("Missing required arguments: \033[1mid\033[0m and \033[1mtransforms\033[0m\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m\n\n\033[1mExample:\033[0m\n  \033[2m$\033[0m clipctl transform abc123 trim,json_pretty\n  \033[2m$\033[0m clipctl transform --store abc123 base64_decode\n\n\033[2mTip: Use 'clipctl transform --list' to see available transforms\033[0m", c.Usage())
+	}
+
+	id := fs.Arg(0)
+	names := splitTransforms(strings.Join(fs.Args()[1:], ","))
+
+	result, err := client.TransformEntry(ctx, id, names, *store)
+	if err != nil {
+		return fmt.Errorf("transforming entry: %w", err)
+	}
+
+	// The result goes to stdout on its own so it can be piped.
+	fmt.Print(result.Result)
+	if !strings.HasSuffix(result.Result, "\n") {
+		fmt.Println()
+	}
+	if result.Entry != nil {
+		fmt.Fprintf(os.Stderr, "\033[2mStored as entry\033[0m \033[1m%s\033[0m\n", result.Entry.Id)
+	}
+
+	return nil
+}
+
+func splitTransforms(arg string) []string {
+	var names []string
+	for _, name := range strings.Split(arg, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/geodask/clipboard-manager/internal/client"
+)
+
+type VaultCommand struct{}
+
+func (c *VaultCommand) Name() string {
+	return "vault"
+}
+
+func (c *VaultCommand) Description() string {
+	return "List or copy recent sensitive entries"
+}
+
+func (c *VaultCommand) Usage() string {
+	return "vault [list] | copy <id> | delete <id> | clear"
+}
+
+func (c *VaultCommand) Execute(ctx context.Context, client *client.Client, args []string) error {
+	action := "list"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	switch action {
+	case "list":
+		return c.list(ctx, client)
+
+	case "clear":
+		if err := client.PurgeVault(ctx); err != nil {
+			return fmt.Errorf("clearing vault: %w", err)
+		}
+		fmt.Println("Vault cleared")
+		return nil
+
+	case "copy", "delete":
+	default:
+		return fmt.Errorf("Unknown action: \033[1m%s\033[0m\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m", action, c.Usage())
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("Missing required argument: \033[1mid\033[0m\n\n\033[1mUsage:\033[0m\n  \033[2m$\033[0m clipctl \033[36m%s\033[0m\n\n\033[1mExample:\033[0m\n  \033[2m$\033[0m clipctl vault copy v1\n\n\033[2mTip: Use 'clipctl vault' to see available vault IDs\033[0m", c.Usage())
+	}
+	id := args[1]
+
+	if action == "delete" {
+		if err := client.DeleteVaultEntry(ctx, id); err != nil {
+			return fmt.Errorf("deleting vault entry: %w", err)
+		}
+		fmt.Printf("Vault entry \033[1m%s\033[0m deleted successfully\n", id)
+		return nil
+	}
+
+	// The daemon writes the secret, so its monitor does not capture it again.
+	entry, err := client.CopyVaultEntry(ctx, id, "")
+	if err != nil {
+		return fmt.Errorf("copying vault entry: %w", err)
+	}
+	fmt.Printf("Copied \033[1m%s\033[0m to the clipboard \033[2m(expires in %ds)\033[0m\n", id, entry.ExpiresInSeconds)
+
+	return nil
+}
+
+func (c *VaultCommand) list(ctx context.Context, client *client.Client) error {
+	resp, err := client.ListVault(ctx)
+	if err != nil {
+		return fmt.Errorf("listing vault: %w", err)
+	}
+
+	if resp.Count == 0 {
+		fmt.Println("\033[2mVault is empty\033[0m")
+		return nil
+	}
+
+	fmt.Println("\033[1mVault:\033[0m")
+	for _, entry := range resp.Entries {
+		fmt.Printf("  \033[36m%-5s\033[0m %-14s \033[2m%3d chars\033[0m  \033[33m%-22s\033[0m \033[2mexpires in %ds\033[0m\n", entry.Id, entry.Preview, entry.Length, entry.Reason, entry.ExpiresInSeconds)
+	}
+
+	return nil
+}
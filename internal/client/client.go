@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	profile    string
 }
 
 func NewClient(socketPath string) *Client {
@@ -30,10 +32,95 @@ func NewClient(socketPath string) *Client {
 	}
 }
 
+// SetProfile scopes history, search and stats requests to a profile. An
+// empty name uses the daemon's active profile.
+func (c *Client) SetProfile(name string) {
+	c.profile = name
+}
+
+// endpoint builds a request URL, adding the profile parameter when set.
+func (c *Client) endpoint(path string, params url.Values) string {
+	if params == nil {
+		params = url.Values{}
+	}
+	if c.profile != "" {
+		params.Set("profile", c.profile)
+	}
+	if len(params) == 0 {
+		return c.baseURL + path
+	}
+	return c.baseURL + path + "?" + params.Encode()
+}
+
+// doJSON sends a request with an optional JSON body and decodes a JSON
+// response into out when it is non-nil.
+func (c *Client) doJSON(ctx context.Context, method, url string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		data, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s", apiErr.Message)
+		}
+		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(data))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return nil
+}
+
 type Entry struct {
-	Id        string    `json:"id"`
-	Content   string    `json:"content"`
-	Timestamp time.Time `json:"timestamp"`
+	Id          string      `json:"id"`
+	Content     string      `json:"content"`
+	Type        string      `json:"type,omitempty"`
+	Language    string      `json:"language,omitempty"`
+	Domain      string      `json:"domain,omitempty"`
+	OriginalURL string      `json:"original_url,omitempty"`
+	Profile     string      `json:"profile,omitempty"`
+	Selection   string      `json:"selection,omitempty"`
+	Source      string      `json:"source,omitempty"`
+	Redactions  []Redaction `json:"redactions,omitempty"`
+	Timestamp   time.Time   `json:"timestamp"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty"`
+	UseCount    int         `json:"use_count"`
+	LastUsedAt  *time.Time  `json:"last_used_at,omitempty"`
+}
+
+// Redaction locates a redaction placeholder in Entry.Content, as byte
+// offsets.
+type Redaction struct {
+	Rule  string `json:"rule"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
 }
 
 type HistoryResponse struct {
@@ -42,12 +129,72 @@ type HistoryResponse struct {
 }
 
 type StatsResponse struct {
-	TotalEntries int    `json:"total_entries"`
-	Status       string `json:"status"`
+	Profile           string          `json:"profile"`
+	TotalEntries      int             `json:"total_entries"`
+	Status            string          `json:"status"`
+	UptimeSeconds     int64           `json:"uptime_seconds"`
+	TotalBytes        int64           `json:"total_bytes"`
+	AverageBytes      float64         `json:"average_bytes"`
+	CountsByType      map[string]int  `json:"counts_by_type"`
+	LargestEntries    []EntrySize     `json:"largest_entries"`
+	Activity          Activity        `json:"activity"`
+	SensitiveSkipped  int             `json:"sensitive_skipped"`
+	SensitiveByReason map[string]int  `json:"sensitive_by_reason"`
+	IgnoredSkipped    int             `json:"ignored_skipped"`
+	IgnoredByRule     map[string]int  `json:"ignored_by_rule"`
+	OldestEntry       *time.Time      `json:"oldest_entry,omitempty"`
+	NewestEntry       *time.Time      `json:"newest_entry,omitempty"`
+	DatabaseBytes     int64           `json:"database_bytes"`
+	Monitor           []MonitorStatus `json:"monitor,omitempty"`
+	Paused            bool            `json:"paused"`
+	PausedUntil       *time.Time      `json:"paused_until,omitempty"`
+}
+
+type MonitorStatus struct {
+	Selection    string     `json:"selection"`
+	Backend      string     `json:"backend"`
+	Mode         string     `json:"mode"`
+	IntervalMs   int64      `json:"interval_ms,omitempty"`
+	ErrorStreak  int        `json:"error_streak"`
+	LastError    string     `json:"last_error,omitempty"`
+	LastErrorAt  *time.Time `json:"last_error_at,omitempty"`
+	LastChangeAt *time.Time `json:"last_change_at,omitempty"`
 }
 
-func (c *Client) GetHistory(ctx context.Context, limit int) ([]Entry, error) {
-	url := fmt.Sprintf("%s/api/v1/history?limit=%d", c.baseURL, limit)
+type EntrySize struct {
+	Id        string    `json:"id"`
+	Type      string    `json:"type"`
+	Size      int       `json:"size"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type Activity struct {
+	Bucket  string           `json:"bucket"`
+	Window  string           `json:"window"`
+	Buckets []ActivityBucket `json:"buckets"`
+}
+
+type ActivityBucket struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+// HistoryFilter narrows GetHistory. Empty fields match every entry.
+type HistoryFilter struct {
+	Language string // code detected as this language
+	Domain   string // URLs on this domain or its subdomains
+}
+
+// GetHistory lists the most recent entries that match filter.
+func (c *Client) GetHistory(ctx context.Context, limit int, filter HistoryFilter) ([]Entry, error) {
+	params := url.Values{"limit": {fmt.Sprintf("%d", limit)}}
+	if filter.Language != "" {
+		params.Set("lang", filter.Language)
+	}
+	if filter.Domain != "" {
+		params.Set("domain", filter.Domain)
+	}
+	url := c.endpoint("/api/v1/history", params)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -74,7 +221,7 @@ func (c *Client) GetHistory(ctx context.Context, limit int) ([]Entry, error) {
 }
 
 func (c *Client) GetEntry(ctx context.Context, id string) (*Entry, error) {
-	url := fmt.Sprintf("%s/api/v1/history/%s", c.baseURL, id)
+	url := c.endpoint("/api/v1/history/"+url.PathEscape(id), nil)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -104,12 +251,28 @@ func (c *Client) GetEntry(ctx context.Context, id string) (*Entry, error) {
 	return &entry, nil
 }
 
+// CopyEntry asks the daemon to put an entry back on the clipboard, or on
+// another selection when selection is set. The returned entry includes the
+// updated usage.
+func (c *Client) CopyEntry(ctx context.Context, id, selection string) (*Entry, error) {
+	params := url.Values{}
+	if selection != "" {
+		params.Set("selection", selection)
+	}
+
+	var entry Entry
+	if err := c.doJSON(ctx, "POST", c.endpoint("/api/v1/history/"+url.PathEscape(id)+"/copy", params), nil, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
 func (c *Client) Search(ctx context.Context, query string, limit int) ([]Entry, error) {
 	params := url.Values{}
 	params.Add("q", query)
 	params.Add("limit", fmt.Sprintf("%d", limit))
 
-	url := fmt.Sprintf("%s/api/v1/search?%s", c.baseURL, params.Encode())
+	url := c.endpoint("/api/v1/search", params)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -135,8 +298,18 @@ func (c *Client) Search(ctx context.Context, query string, limit int) ([]Entry,
 	return historyResp.Entries, nil
 }
 
-func (c *Client) GetStats(ctx context.Context) (*StatsResponse, error) {
-	url := fmt.Sprintf("%s/api/v1/stats", c.baseURL)
+// GetStats fetches daemon statistics. bucket is "hour" or "day" and window
+// is a duration such as "24h" or "7d"; empty values use the daemon defaults.
+func (c *Client) GetStats(ctx context.Context, bucket, window string) (*StatsResponse, error) {
+	params := url.Values{}
+	if bucket != "" {
+		params.Add("bucket", bucket)
+	}
+	if window != "" {
+		params.Add("window", window)
+	}
+
+	url := c.endpoint("/api/v1/stats", params)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -162,8 +335,58 @@ func (c *Client) GetStats(ctx context.Context) (*StatsResponse, error) {
 	return &stats, nil
 }
 
+type MaintenanceReport struct {
+	StartedAt       time.Time `json:"started_at"`
+	DurationMs      int64     `json:"duration_ms"`
+	IntegrityOK     bool      `json:"integrity_ok"`
+	IntegrityErrors []string  `json:"integrity_errors,omitempty"`
+	FullVacuum      bool      `json:"full_vacuum"`
+	SizeBefore      int64     `json:"size_before"`
+	SizeAfter       int64     `json:"size_after"`
+	ReclaimedBytes  int64     `json:"reclaimed_bytes"`
+}
+
+func (c *Client) GetMaintenance(ctx context.Context) (*MaintenanceReport, error) {
+	return c.maintenance(ctx, "GET")
+}
+
+func (c *Client) RunMaintenance(ctx context.Context) (*MaintenanceReport, error) {
+	return c.maintenance(ctx, "POST")
+}
+
+func (c *Client) maintenance(ctx context.Context, method string) (*MaintenanceReport, error) {
+	url := fmt.Sprintf("%s/api/v1/admin/maintenance", c.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("no maintenance run recorded yet")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var report MaintenanceReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &report, nil
+}
+
 func (c *Client) DeleteEntry(ctx context.Context, id string) error {
-	url := fmt.Sprintf("%s/api/v1/history/%s", c.baseURL, id)
+	url := c.endpoint("/api/v1/history/"+url.PathEscape(id), nil)
 
 	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
@@ -208,3 +431,272 @@ func (c *Client) Ping(ctx context.Context) error {
 
 	return nil
 }
+
+type Profile struct {
+	Name            string    `json:"name"`
+	RetentionMaxAge string    `json:"retention_max_age,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	Active          bool      `json:"active"`
+}
+
+type ProfilesResponse struct {
+	Profiles []Profile `json:"profiles"`
+	Active   string    `json:"active"`
+}
+
+func (c *Client) ListProfiles(ctx context.Context) (*ProfilesResponse, error) {
+	var profiles ProfilesResponse
+	if err := c.doJSON(ctx, "GET", c.baseURL+"/api/v1/profiles", nil, &profiles); err != nil {
+		return nil, err
+	}
+	return &profiles, nil
+}
+
+// CreateProfile creates a profile. retention is a duration such as "72h" or
+// "30d"; empty uses the daemon-wide retention.
+func (c *Client) CreateProfile(ctx context.Context, name, retention string) (*Profile, error) {
+	body := map[string]string{"name": name, "retention": retention}
+
+	var profile Profile
+	if err := c.doJSON(ctx, "POST", c.baseURL+"/api/v1/profiles", body, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (c *Client) DeleteProfile(ctx context.Context, name string) error {
+	return c.doJSON(ctx, "DELETE", c.baseURL+"/api/v1/profiles/"+url.PathEscape(name), nil, nil)
+}
+
+func (c *Client) ActivateProfile(ctx context.Context, name string) (*Profile, error) {
+	var profile Profile
+	if err := c.doJSON(ctx, "POST", c.baseURL+"/api/v1/profiles/"+url.PathEscape(name)+"/activate", nil, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+type Rule struct {
+	Name     string   `json:"name"`
+	Pattern  string   `json:"pattern,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+	Severity string   `json:"severity"`
+	Action   string   `json:"action"`
+	Builtin  bool     `json:"builtin"`
+	Detector bool     `json:"detector"`
+}
+
+type RulesResponse struct {
+	Rules []Rule `json:"rules"`
+	Count int    `json:"count"`
+}
+
+type RuleMatch struct {
+	Analyzer   string `json:"analyzer,omitempty"`
+	Rule       string `json:"rule"`
+	Severity   string `json:"severity"`
+	Confidence string `json:"confidence"`
+	Action     string `json:"action"`
+	Start      int    `json:"start"`
+	End        int    `json:"end"`
+	Text       string `json:"text"`
+}
+
+func (c *Client) ListRules(ctx context.Context) (*RulesResponse, error) {
+	var rules RulesResponse
+	if err := c.doJSON(ctx, "GET", c.baseURL+"/api/v1/rules", nil, &rules); err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+func (c *Client) ReloadRules(ctx context.Context) (*RulesResponse, error) {
+	var rules RulesResponse
+	if err := c.doJSON(ctx, "POST", c.baseURL+"/api/v1/rules/reload", nil, &rules); err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+// TestRules runs content through the daemon's rules without storing it. The
+// result is the same as Analyze's.
+func (c *Client) TestRules(ctx context.Context, content string) (*AnalyzeResult, error) {
+	body := map[string]string{"content": content}
+
+	var result AnalyzeResult
+	if err := c.doJSON(ctx, "POST", c.baseURL+"/api/v1/rules/test", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+type Finding struct {
+	Analyzer string  `json:"analyzer"`
+	Kind     string  `json:"kind"`
+	Value    string  `json:"value"`
+	Score    float64 `json:"score"`
+}
+
+type AnalyzeResult struct {
+	Outcome     string      `json:"outcome"`
+	IgnoredBy   string      `json:"ignored_by,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	Language    string      `json:"language,omitempty"`
+	Domain      string      `json:"domain,omitempty"`
+	Tags        []string    `json:"tags,omitempty"`
+	Sensitive   bool        `json:"sensitive"`
+	Reason      string      `json:"reason,omitempty"`
+	Severity    string      `json:"severity,omitempty"`
+	Confidence  string      `json:"confidence,omitempty"`
+	TTL         string      `json:"ttl,omitempty"`
+	Findings    []Finding   `json:"findings"`
+	Matches     []RuleMatch `json:"matches"`
+	Stored      string      `json:"stored,omitempty"`
+	Redactions  []Redaction `json:"redactions,omitempty"`
+	OriginalURL string      `json:"original_url,omitempty"`
+}
+
+// Analyze runs content through the daemon's ignore rules and analyzers
+// without storing it.
+func (c *Client) Analyze(ctx context.Context, content string) (*AnalyzeResult, error) {
+	body := map[string]string{"content": content}
+
+	var result AnalyzeResult
+	if err := c.doJSON(ctx, "POST", c.baseURL+"/api/v1/analyze", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+type VaultEntry struct {
+	Id               string    `json:"id"`
+	Preview          string    `json:"preview"`
+	Length           int       `json:"length"`
+	Type             string    `json:"type"`
+	Reason           string    `json:"reason"`
+	Severity         string    `json:"severity,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	ExpiresInSeconds int64     `json:"expires_in_seconds"`
+	Content          string    `json:"content,omitempty"`
+}
+
+type VaultResponse struct {
+	Entries []VaultEntry `json:"entries"`
+	Count   int          `json:"count"`
+}
+
+func (c *Client) ListVault(ctx context.Context) (*VaultResponse, error) {
+	var vault VaultResponse
+	if err := c.doJSON(ctx, "GET", c.baseURL+"/api/v1/vault", nil, &vault); err != nil {
+		return nil, err
+	}
+	return &vault, nil
+}
+
+// GetVaultEntry returns a vault entry including its content.
+func (c *Client) GetVaultEntry(ctx context.Context, id string) (*VaultEntry, error) {
+	var entry VaultEntry
+	if err := c.doJSON(ctx, "GET", c.baseURL+"/api/v1/vault/"+url.PathEscape(id), nil, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// CopyVaultEntry has the daemon put a vault entry on the clipboard, or on
+// another selection when selection is set.
+func (c *Client) CopyVaultEntry(ctx context.Context, id, selection string) (*VaultEntry, error) {
+	endpoint := c.baseURL + "/api/v1/vault/" + url.PathEscape(id) + "/copy"
+	if selection != "" {
+		endpoint += "?" + url.Values{"selection": {selection}}.Encode()
+	}
+
+	var entry VaultEntry
+	if err := c.doJSON(ctx, "POST", endpoint, nil, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (c *Client) DeleteVaultEntry(ctx context.Context, id string) error {
+	return c.doJSON(ctx, "DELETE", c.baseURL+"/api/v1/vault/"+url.PathEscape(id), nil, nil)
+}
+
+func (c *Client) PurgeVault(ctx context.Context) error {
+	return c.doJSON(ctx, "DELETE", c.baseURL+"/api/v1/vault", nil, nil)
+}
+
+type Transform struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// TransformResult holds the transformed content. Entry is set when the
+// result was stored.
+type TransformResult struct {
+	Result     string   `json:"result"`
+	Transforms []string `json:"transforms"`
+	Entry      *Entry   `json:"entry,omitempty"`
+}
+
+func (c *Client) ListTransforms(ctx context.Context) ([]Transform, error) {
+	var result struct {
+		Transforms []Transform `json:"transforms"`
+	}
+	if err := c.doJSON(ctx, "GET", c.baseURL+"/api/v1/transforms", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Transforms, nil
+}
+
+// TransformEntry applies transforms to an entry in order, storing the
+// result as a new entry when store is set.
+func (c *Client) TransformEntry(ctx context.Context, id string, transforms []string, store bool) (*TransformResult, error) {
+	body := map[string]any{"transforms": transforms, "store": store}
+
+	var result TransformResult
+	if err := c.doJSON(ctx, "POST", c.endpoint("/api/v1/history/"+url.PathEscape(id)+"/transform", nil), body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MonitorState says whether capture is paused and how each selection is
+// watched.
+type MonitorState struct {
+	Paused      bool            `json:"paused"`
+	PausedSince *time.Time      `json:"paused_since,omitempty"`
+	PausedUntil *time.Time      `json:"paused_until,omitempty"`
+	Sources     []MonitorStatus `json:"sources"`
+}
+
+func (c *Client) GetMonitor(ctx context.Context) (*MonitorState, error) {
+	var state MonitorState
+	if err := c.doJSON(ctx, "GET", c.baseURL+"/api/v1/monitor", nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Pause stops capture for duration, e.g. "10m", or until Resume when
+// duration is empty.
+func (c *Client) Pause(ctx context.Context, duration string) (*MonitorState, error) {
+	endpoint := c.baseURL + "/api/v1/monitor/pause"
+	if duration != "" {
+		endpoint += "?" + url.Values{"for": {duration}}.Encode()
+	}
+
+	var state MonitorState
+	if err := c.doJSON(ctx, "POST", endpoint, nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (c *Client) Resume(ctx context.Context) (*MonitorState, error) {
+	var state MonitorState
+	if err := c.doJSON(ctx, "POST", c.baseURL+"/api/v1/monitor/resume", nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"flag"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -9,12 +11,19 @@ type Config struct {
 	Database DatabaseConfig
 	API      APIConfig
 	Monitor  MonitorConfig
+	Sources  SourcesConfig
+	Capture  CaptureConfig
+	Analyzer AnalyzerConfig
+	Ignore   IgnoreConfig
+	Vault    VaultConfig
 	Daemon   DaemonConfig
 	Logging  LoggingConfig
 }
 
 type DatabaseConfig struct {
-	Path string
+	Path         string
+	BusyTimeout  time.Duration
+	MaxReadConns int
 }
 
 type APIConfig struct {
@@ -25,15 +34,87 @@ type APIConfig struct {
 }
 
 type MonitorConfig struct {
+	Backend      string        // auto, wayland, x11 or poll
+	Clipboard    string        // auto, xclip, xsel, wl-clipboard, tmux or command
+	ReadCommand  string        // for the command clipboard, run with sh -c; {selection} is replaced
+	WriteCommand string        // for the command clipboard; gets the content on stdin
+	PollInterval time.Duration // for the poll backend, and as the fallback when events fail
+
+	// Polling slows down from PollInterval to MaxPollInterval while the
+	// clipboard is unchanged, and to at most ErrorBackoffMax while reads fail.
+	MaxPollInterval time.Duration
+	ErrorBackoffMax time.Duration
+
+	Selections        []string      // clipboard, primary and/or secondary
+	SelectionDebounce time.Duration // how long primary and secondary must be stable before capture
+	DebounceClipboard bool          // wait for SelectionDebounce on the clipboard as well
+
+	PersistPause bool // keep a capture pause across daemon restarts
+}
+
+// SourcesConfig adds capture sources besides the clipboard monitor.
+type SourcesConfig struct {
+	FeedFIFO     string        // named pipe written by clipd-feed; empty disables it
+	WatchFiles   []string      // files whose appended lines become entries
+	TmuxBuffers  bool          // record the newest tmux paste buffer
+	PollInterval time.Duration // for watched files
+}
+
+// CaptureConfig cleans up captures before they are processed. Length limits
+// are the ignore rules' MinLength and MaxLength.
+type CaptureConfig struct {
+	Overflow            string // reject or truncate captures longer than Ignore.MaxLength
+	TrimTrailingNewline bool   // drop line breaks at the end of the content
+	NormalizeNFC        bool   // store content in Unicode normalization form C
+}
+
+type AnalyzerConfig struct {
+	RulesFile         string
+	DisabledDetectors []string
+	SensitiveMode     string // block or redact
+
+	EntropyEnabled         bool
+	EntropyMinLength       int
+	EntropyMinClasses      int     // of upper, lower, digit, symbol; base64-like tokens only
+	EntropyBase64Threshold float64 // bits per character
+	EntropyHexThreshold    float64 // bits per character
+
+	StageTimeout time.Duration // per analyzer in the pipeline; zero means no limit
+
+	PIIPolicy map[string]string // PII category -> allow, redact, block or short_ttl
+	ShortTTL  time.Duration     // lifetime of entries matched by a short_ttl rule
+
+	CleanURLs      bool
+	TrackingParams []string // query parameters stripped from URLs; "utm_*" matches a prefix
+}
+
+// IgnoreConfig lists captures that are dropped before analysis and never
+// recorded anywhere.
+type IgnoreConfig struct {
+	Patterns   []string // regular expressions matched against the content
+	MinLength  int      // in characters; shorter captures are ignored
+	MaxLength  int      // in characters; zero means no limit, see Capture.Overflow
+	Whitespace bool     // ignore captures that are only whitespace
+	SourceApps []string // applications, when the monitor can tell
+}
+
+type VaultConfig struct {
+	Enabled    bool
+	TTL        time.Duration
+	MaxEntries int
 }
 
 type DaemonConfig struct {
 	ShutdownTimeout   time.Duration
-	PollInterval      time.Duration
 	RetentionEnabled  bool
 	RetentionMaxAge   time.Duration
 	RetentionInterval time.Duration
+	ExpiryInterval    time.Duration
+	WriteBackURLs     bool // put cleaned URLs back on the clipboard
 	PIDFile           string
+
+	MaintenanceEnabled  bool
+	MaintenanceInterval time.Duration
 }
 
 type LoggingConfig struct {
@@ -50,18 +131,119 @@ func Load() (*Config, error) {
 	cfg := Default()
 
 	flag.StringVar(&cfg.Database.Path, "db", cfg.Database.Path, "Path to SQLite database")
+	flag.DurationVar(&cfg.Database.BusyTimeout, "db-busy-timeout", cfg.Database.BusyTimeout, "How long SQLite waits on a locked database")
+	flag.IntVar(&cfg.Database.MaxReadConns, "db-max-read-conns", cfg.Database.MaxReadConns, "Maximum number of concurrent read connections")
 
 	flag.StringVar(&cfg.API.SocketPath, "socket", cfg.API.SocketPath, "Path to Unix socket for API")
 	flag.DurationVar(&cfg.API.ReadTimeout, "read-timeout", cfg.API.ReadTimeout, "HTTP read timeout")
 	flag.DurationVar(&cfg.API.WriteTimeout, "write-timeout", cfg.API.WriteTimeout, "HTTP write timeout")
 	flag.DurationVar(&cfg.API.IdleTimeout, "idle-timeout", cfg.API.IdleTimeout, "HTTP idle timeout")
 
-	flag.DurationVar(&cfg.Daemon.PollInterval, "poll-interval", cfg.Daemon.PollInterval, "Clipboard polling interval")
+	flag.StringVar(&cfg.Analyzer.RulesFile, "rules-file", cfg.Analyzer.RulesFile, "Path to JSON file with custom sensitive-content rules")
+	flag.StringVar(&cfg.Analyzer.SensitiveMode, "sensitive-mode", cfg.Analyzer.SensitiveMode, "What to do with detected secrets (block, redact)")
+	flag.Func("disable-detectors", "Comma-separated credential detectors to turn off (e.g. jwt,credit_card)", func(value string) error {
+		cfg.Analyzer.DisabledDetectors = nil
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.Analyzer.DisabledDetectors = append(cfg.Analyzer.DisabledDetectors, name)
+			}
+		}
+		return nil
+	})
+	flag.BoolVar(&cfg.Analyzer.EntropyEnabled, "entropy-enabled", cfg.Analyzer.EntropyEnabled, "Detect unlabelled high-entropy secrets")
+	flag.IntVar(&cfg.Analyzer.EntropyMinLength, "entropy-min-length", cfg.Analyzer.EntropyMinLength, "Minimum token length checked for entropy")
+	flag.IntVar(&cfg.Analyzer.EntropyMinClasses, "entropy-min-classes", cfg.Analyzer.EntropyMinClasses, "Minimum character classes in a base64-like secret")
+	flag.Float64Var(&cfg.Analyzer.EntropyBase64Threshold, "entropy-base64-threshold", cfg.Analyzer.EntropyBase64Threshold, "Entropy threshold for base64-like tokens (bits/char)")
+	flag.Float64Var(&cfg.Analyzer.EntropyHexThreshold, "entropy-hex-threshold", cfg.Analyzer.EntropyHexThreshold, "Entropy threshold for hex tokens (bits/char)")
+
+	flag.DurationVar(&cfg.Analyzer.StageTimeout, "analyzer-timeout", cfg.Analyzer.StageTimeout, "Time limit for each analyzer in the pipeline (0 = none)")
+	flag.Func("pii-policy", "Comma-separated category=action overrides for PII (e.g. email=allow,iban=block,phone=redact)", func(value string) error {
+		for _, pair := range strings.Split(value, ",") {
+			if pair = strings.TrimSpace(pair); pair == "" {
+				continue
+			}
+			category, action, ok := strings.Cut(pair, "=")
+			if !ok {
+				return fmt.Errorf("invalid PII policy %q (want category=action)", pair)
+			}
+			cfg.Analyzer.PIIPolicy[strings.TrimSpace(category)] = strings.TrimSpace(action)
+		}
+		return nil
+	})
+	flag.DurationVar(&cfg.Analyzer.ShortTTL, "short-ttl", cfg.Analyzer.ShortTTL, "How long entries matched by a short_ttl rule or PII policy are kept")
+
+	flag.BoolVar(&cfg.Analyzer.CleanURLs, "clean-urls", cfg.Analyzer.CleanURLs, "Store URLs without tracking parameters, in canonical form")
+	flag.Func("tracking-params", "Comma-separated query parameters stripped from URLs; a trailing * matches a prefix (e.g. utm_*,fbclid)", func(value string) error {
+		cfg.Analyzer.TrackingParams = nil
+		for _, param := range strings.Split(value, ",") {
+			if param = strings.TrimSpace(param); param != "" {
+				cfg.Analyzer.TrackingParams = append(cfg.Analyzer.TrackingParams, param)
+			}
+		}
+		return nil
+	})
+
+	flag.StringVar(&cfg.Sources.FeedFIFO, "feed-fifo", cfg.Sources.FeedFIFO, "Named pipe that clipd-feed writes entries to (empty = disabled)")
+	flag.Func("watch-file", "File whose appended lines are recorded as entries (repeatable)", func(value string) error {
+		cfg.Sources.WatchFiles = append(cfg.Sources.WatchFiles, value)
+		return nil
+	})
+	flag.BoolVar(&cfg.Sources.TmuxBuffers, "tmux-buffers", cfg.Sources.TmuxBuffers, "Also record the newest tmux paste buffer")
+	flag.DurationVar(&cfg.Sources.PollInterval, "watch-interval", cfg.Sources.PollInterval, "How often watched files are checked for new lines")
+
+	flag.StringVar(&cfg.Capture.Overflow, "capture-overflow", cfg.Capture.Overflow, "What to do with captures over -ignore-max-length (reject, truncate)")
+	flag.BoolVar(&cfg.Capture.TrimTrailingNewline, "capture-trim-newline", cfg.Capture.TrimTrailingNewline, "Remove trailing line breaks from captures")
+	flag.BoolVar(&cfg.Capture.NormalizeNFC, "capture-nfc", cfg.Capture.NormalizeNFC, "Normalize captures to Unicode NFC")
+
+	flag.Func("ignore-pattern", "Regular expression for content that is never recorded (repeatable)", func(value string) error {
+		cfg.Ignore.Patterns = append(cfg.Ignore.Patterns, value)
+		return nil
+	})
+	flag.IntVar(&cfg.Ignore.MinLength, "ignore-min-length", cfg.Ignore.MinLength, "Ignore captures shorter than this many characters")
+	flag.IntVar(&cfg.Ignore.MaxLength, "ignore-max-length", cfg.Ignore.MaxLength, "Ignore captures longer than this many characters (0 = no limit)")
+	flag.BoolVar(&cfg.Ignore.Whitespace, "ignore-whitespace", cfg.Ignore.Whitespace, "Ignore captures that are only whitespace")
+	flag.Func("ignore-apps", "Comma-separated applications whose copies are never recorded (e.g. KeePassXC,1Password)", func(value string) error {
+		cfg.Ignore.SourceApps = nil
+		for _, app := range strings.Split(value, ",") {
+			if app = strings.TrimSpace(app); app != "" {
+				cfg.Ignore.SourceApps = append(cfg.Ignore.SourceApps, app)
+			}
+		}
+		return nil
+	})
+
+	flag.BoolVar(&cfg.Vault.Enabled, "vault-enabled", cfg.Vault.Enabled, "Keep sensitive entries in a memory-only vault for a short time")
+	flag.DurationVar(&cfg.Vault.TTL, "vault-ttl", cfg.Vault.TTL, "How long sensitive entries stay in the vault")
+	flag.IntVar(&cfg.Vault.MaxEntries, "vault-max-entries", cfg.Vault.MaxEntries, "Maximum number of entries held in the vault")
+
+	flag.StringVar(&cfg.Monitor.Backend, "monitor", cfg.Monitor.Backend, "Clipboard monitor backend (auto, wayland, x11, poll)")
+	flag.Func("selections", "Comma-separated selections to capture (clipboard, primary, secondary)", func(value string) error {
+		cfg.Monitor.Selections = nil
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.Monitor.Selections = append(cfg.Monitor.Selections, name)
+			}
+		}
+		return nil
+	})
+	flag.DurationVar(&cfg.Monitor.SelectionDebounce, "selection-debounce", cfg.Monitor.SelectionDebounce, "How long the primary or secondary selection must stay unchanged before it is captured")
+	flag.BoolVar(&cfg.Monitor.DebounceClipboard, "debounce-clipboard", cfg.Monitor.DebounceClipboard, "Apply -selection-debounce to the clipboard as well")
+	flag.DurationVar(&cfg.Monitor.PollInterval, "poll-interval", cfg.Monitor.PollInterval, "Clipboard polling interval after a change, also used when change events fail")
+	flag.StringVar(&cfg.Monitor.Clipboard, "clipboard", cfg.Monitor.Clipboard, "Clipboard backend (auto, xclip, xsel, wl-clipboard, tmux, command)")
+	flag.StringVar(&cfg.Monitor.ReadCommand, "clipboard-read-command", cfg.Monitor.ReadCommand, "Shell command printing the clipboard, for --clipboard command; {selection} is replaced")
+	flag.StringVar(&cfg.Monitor.WriteCommand, "clipboard-write-command", cfg.Monitor.WriteCommand, "Shell command setting the clipboard from stdin, for --clipboard command")
+	flag.BoolVar(&cfg.Monitor.PersistPause, "persist-pause", cfg.Monitor.PersistPause, "Keep a capture pause across daemon restarts")
+	flag.DurationVar(&cfg.Monitor.MaxPollInterval, "max-poll-interval", cfg.Monitor.MaxPollInterval, "Longest polling interval while the clipboard is unchanged")
+	flag.DurationVar(&cfg.Monitor.ErrorBackoffMax, "error-backoff-max", cfg.Monitor.ErrorBackoffMax, "Longest wait between polls while the clipboard cannot be read")
 	flag.DurationVar(&cfg.Daemon.ShutdownTimeout, "shutdown-timeout", cfg.Daemon.ShutdownTimeout, "Graceful shutdown timeout")
 	flag.BoolVar(&cfg.Daemon.RetentionEnabled, "retention-enabled", cfg.Daemon.RetentionEnabled, "Enable clipboard retention")
 	flag.DurationVar(&cfg.Daemon.RetentionMaxAge, "retention-max-age", cfg.Daemon.RetentionMaxAge, "Max age of retained clipboard entries")
 	flag.DurationVar(&cfg.Daemon.RetentionInterval, "retention-interval", cfg.Daemon.RetentionInterval, "Interval for retention cleanup")
+	flag.DurationVar(&cfg.Daemon.ExpiryInterval, "expiry-interval", cfg.Daemon.ExpiryInterval, "Interval for deleting expired short-TTL entries")
+	flag.BoolVar(&cfg.Daemon.WriteBackURLs, "url-write-back", cfg.Daemon.WriteBackURLs, "Replace copied URLs on the clipboard with their cleaned form")
 	flag.StringVar(&cfg.Daemon.PIDFile, "pid-file", cfg.Daemon.PIDFile, "Path to PID file")
+	flag.BoolVar(&cfg.Daemon.MaintenanceEnabled, "maintenance-enabled", cfg.Daemon.MaintenanceEnabled, "Enable scheduled database maintenance")
+	flag.DurationVar(&cfg.Daemon.MaintenanceInterval, "maintenance-interval", cfg.Daemon.MaintenanceInterval, "Interval for database maintenance")
 
 	flag.StringVar(&cfg.Logging.Level, "log-level", cfg.Logging.Level, "Log level (debug, info, warn, error)")
 	flag.StringVar(&cfg.Logging.Format, "log-format", cfg.Logging.Format, "Log format (text, json)")
@@ -9,7 +9,9 @@ import (
 func Default() *Config {
 	return &Config{
 		Database: DatabaseConfig{
-			Path: "./clipboard.db",
+			Path:         "./clipboard.db",
+			BusyTimeout:  5 * time.Second,
+			MaxReadConns: 4,
 		},
 		API: APIConfig{
 			SocketPath:   "/tmp/clipd.sock",
@@ -17,14 +19,66 @@ func Default() *Config {
 			WriteTimeout: 10 * time.Second,
 			IdleTimeout:  10 * time.Second,
 		},
-		Monitor: MonitorConfig{},
+		Monitor: MonitorConfig{
+			Backend:      "auto",
+			Clipboard:    "auto",
+			PollInterval: 500 * time.Millisecond,
+
+			MaxPollInterval: 5 * time.Second,
+			ErrorBackoffMax: time.Minute,
+
+			Selections:        []string{"clipboard"},
+			SelectionDebounce: 500 * time.Millisecond,
+		},
+		Sources: SourcesConfig{
+			PollInterval: time.Second,
+		},
+		Capture: CaptureConfig{
+			Overflow: "reject",
+		},
+		Analyzer: AnalyzerConfig{
+			RulesFile:     "",
+			SensitiveMode: "block",
+
+			EntropyEnabled:         true,
+			EntropyMinLength:       20,
+			EntropyMinClasses:      3,
+			EntropyBase64Threshold: 4.5,
+			EntropyHexThreshold:    3.0,
+
+			PIIPolicy: map[string]string{
+				"email":       "redact",
+				"phone":       "redact",
+				"iban":        "block",
+				"national_id": "block",
+				"address":     "redact",
+			},
+			ShortTTL: 10 * time.Minute,
+
+			CleanURLs: true,
+			TrackingParams: []string{
+				"utm_*", "fbclid", "gclid", "dclid", "gbraid", "wbraid", "msclkid",
+				"mc_cid", "mc_eid", "igshid", "yclid", "_hsenc", "_hsmi", "mkt_tok",
+			},
+		},
+		Ignore: IgnoreConfig{
+			Whitespace: true,
+		},
+		Vault: VaultConfig{
+			Enabled:    false,
+			TTL:        60 * time.Second,
+			MaxEntries: 20,
+		},
 		Daemon: DaemonConfig{
 			ShutdownTimeout:   5 * time.Second,
-			PollInterval:      500 * time.Millisecond,
 			RetentionEnabled:  true,
 			RetentionMaxAge:   30 * 24 * time.Hour, // 30 days
 			RetentionInterval: 1 * 24 * time.Hour,  // 1 day
+			ExpiryInterval:    30 * time.Second,
 			PIDFile:           fmt.Sprintf("/tmp/clipd-%d.pid", os.Geteuid()),
+
+			MaintenanceEnabled:  true,
+			MaintenanceInterval: 1 * 24 * time.Hour, // 1 day
 		},
 		Logging: LoggingConfig{
 			Level:      "info",
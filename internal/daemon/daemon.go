@@ -10,12 +10,37 @@ import (
 
 	"github.com/geodask/clipboard-manager/internal/config"
 	"github.com/geodask/clipboard-manager/internal/domain"
+	"github.com/geodask/clipboard-manager/internal/monitor"
 	"github.com/geodask/clipboard-manager/internal/service"
 	"golang.org/x/sync/errgroup"
 )
 
 type Monitor interface {
-	Check() (entry *domain.ClipboardEntry, changed bool, err error)
+	Events(ctx context.Context) <-chan monitor.Event
+	Backend() string
+}
+
+// Source is a capture source besides the clipboard monitor, such as a feed
+// pipe or a watched file. Events is closed once ctx is done, or earlier if
+// the source runs out.
+type Source interface {
+	Name() string
+	Events(ctx context.Context) <-chan monitor.Event
+}
+
+// clipboardSource is how entries from the clipboard monitor are tagged.
+const clipboardSource = "clipboard"
+
+// CaptureFilter cleans up captures from every source before they reach the
+// service. Apply returns why an entry is dropped, or "" to process it.
+type CaptureFilter interface {
+	Apply(entry *domain.ClipboardEntry) string
+}
+
+// ClipboardWriter is implemented by monitors that can also set the
+// clipboard.
+type ClipboardWriter interface {
+	Write(content string) error
 }
 
 type Storage interface {
@@ -23,12 +48,17 @@ type Storage interface {
 }
 
 type Analyzer interface {
-	Analyze(entry *domain.ClipboardEntry) *domain.Analysis
+	Analyze(ctx context.Context, entry *domain.ClipboardEntry) *domain.Analysis
 }
 
 type Service interface {
 	ProcessNewEntry(ctx context.Context, entry *domain.ClipboardEntry) (*domain.ClipboardEntry, error)
-	DeleteOlderThan(ctx context.Context, cutoff time.Time) (int, error)
+	ApplyRetention(ctx context.Context, defaultMaxAge time.Duration) (int, error)
+	ExpireEntries(ctx context.Context) (int, error)
+	RunMaintenance(ctx context.Context) (*domain.MaintenanceReport, error)
+	ReloadRules(ctx context.Context) ([]domain.Rule, error)
+	PurgeVault() int
+	PauseState() domain.PauseState
 }
 
 type APIServer interface {
@@ -38,16 +68,23 @@ type APIServer interface {
 
 type Daemon struct {
 	monitor           Monitor
+	sources           []Source
+	filter            CaptureFilter
 	service           Service
 	apiServer         APIServer
 	startTime         time.Time
-	pollInterval      time.Duration
 	shutdownTimeout   time.Duration
 	retentionEnabled  bool
 	retentionMaxAge   time.Duration
 	retentionInterval time.Duration
-	pidFile           *PIDFile
-	logger            *slog.Logger
+	expiryInterval    time.Duration
+	writeBackURLs     bool
+
+	maintenanceEnabled  bool
+	maintenanceInterval time.Duration
+
+	pidFile *PIDFile
+	logger  *slog.Logger
 }
 
 func NewDaemon(
@@ -61,28 +98,61 @@ func NewDaemon(
 		monitor:           monitor,
 		service:           service,
 		apiServer:         apiServer,
-		pollInterval:      cfg.PollInterval,
 		shutdownTimeout:   cfg.ShutdownTimeout,
 		retentionEnabled:  cfg.RetentionEnabled,
 		retentionMaxAge:   cfg.RetentionMaxAge,
 		retentionInterval: cfg.RetentionInterval,
-		pidFile:           NewPIDFile(cfg.PIDFile),
-		startTime:         time.Now(),
-		logger:            logger,
+		expiryInterval:    cfg.ExpiryInterval,
+		writeBackURLs:     cfg.WriteBackURLs,
+
+		maintenanceEnabled:  cfg.MaintenanceEnabled,
+		maintenanceInterval: cfg.MaintenanceInterval,
+
+		pidFile:   NewPIDFile(cfg.PIDFile),
+		startTime: time.Now(),
+		logger:    logger,
 	}
 }
 
+// AddSource captures from source as well as from the clipboard monitor.
+// It must be called before Run.
+func (d *Daemon) AddSource(source Source) {
+	d.sources = append(d.sources, source)
+}
+
+// SetCaptureFilter runs every capture through filter before it is
+// processed. It must be called before Run.
+func (d *Daemon) SetCaptureFilter(filter CaptureFilter) {
+	d.filter = filter
+}
+
 func (d *Daemon) Run(ctx context.Context) error {
 	g, ctx := errgroup.WithContext(ctx)
 
 	g.Go(func() error {
-		return d.runMonitorLoop(ctx)
+		d.logger.Info("clipboard monitor started", "backend", d.monitor.Backend())
+		return d.runSourceLoop(ctx, clipboardSource, d.monitor.Events(ctx))
 	})
 
+	for _, source := range d.sources {
+		g.Go(func() error {
+			d.logger.Info("capture source started", "source", source.Name())
+			return d.runSourceLoop(ctx, source.Name(), source.Events(ctx))
+		})
+	}
+
 	g.Go(func() error {
 		return d.runRetentionLoop(ctx)
 	})
 
+	g.Go(func() error {
+		return d.runExpiryLoop(ctx)
+	})
+
+	g.Go(func() error {
+		return d.runMaintenanceLoop(ctx)
+	})
+
 	g.Go(func() error {
 		return d.apiServer.Start(ctx)
 	})
@@ -93,7 +163,13 @@ func (d *Daemon) Run(ctx context.Context) error {
 		d.logger.Info("initiating graceful shutdown", "timeout", d.shutdownTimeout)
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), d.shutdownTimeout)
 		defer cancel()
-		return d.apiServer.Shutdown(shutdownCtx)
+		err := d.apiServer.Shutdown(shutdownCtx)
+
+		if purged := d.service.PurgeVault(); purged > 0 {
+			d.logger.Info("purged vault", "entries", purged)
+		}
+
+		return err
 	})
 
 	return g.Wait()
@@ -144,46 +220,89 @@ func (d *Daemon) Start() error {
 }
 
 func (d *Daemon) PerformRetention(ctx context.Context) (int, error) {
-	cutoff := time.Now().Add(-d.retentionMaxAge)
-	deleted, err := d.service.DeleteOlderThan(ctx, cutoff)
-	return deleted, err
+	return d.service.ApplyRetention(ctx, d.retentionMaxAge)
 }
 
-func (d *Daemon) runMonitorLoop(ctx context.Context) error {
-	ticker := time.NewTicker(d.pollInterval)
-	defer ticker.Stop()
+// runSourceLoop records the entries from one source, tagged with its name.
+// A source that runs out stops only its own loop.
+func (d *Daemon) runSourceLoop(ctx context.Context, name string, events <-chan monitor.Event) error {
+	errLog := &errorLog{interval: monitorErrorLogInterval}
 
-	d.logger.Info("clipboard monitor started", "poll_interval", d.pollInterval)
 	for {
 		select {
-		case <-ticker.C:
-			entry, changed, err := d.monitor.Check()
-			if err != nil {
-				d.logger.Error("monitor check failed", "error", err)
+		case event, ok := <-events:
+			if !ok {
+				d.logger.Info("capture source stopping", "source", name)
+				return ctx.Err()
+			}
+			if event.Err != nil {
+				if log, suppressed := errLog.failed(time.Now()); log {
+					d.logger.Error("monitor check failed", "source", name, "error", event.Err, "suppressed", suppressed)
+				}
 				continue
 			}
-			if changed {
-				stored, err := d.service.ProcessNewEntry(ctx, entry)
-				if err != nil {
-					var sensitiveErr *service.SensitiveContentError
-					if errors.As(err, &sensitiveErr) {
-						d.logger.Debug("skipped sensitive content", "reason", sensitiveErr.Reason, "content_length", len(entry.Content))
-					} else {
-						d.logger.Error("failed to process entry", "error", err, "content_length", len(entry.Content))
-					}
+			if failures := errLog.recovered(); failures > 0 {
+				d.logger.Info("capture source recovered", "source", name, "failures", failures)
+			}
+			event.Entry.Metadata.Source = name
+			if d.filter != nil {
+				if reason := d.filter.Apply(event.Entry); reason != "" {
+					d.logger.Debug("capture filtered", "source", name, "reason", reason, "content_length", len(event.Entry.Content))
 					continue
 				}
-
-				d.logger.Info("stored clipboard entry", "id", stored.Id, "content_length", len(stored.Content), "timestamp", stored.Timestamp)
 			}
+			d.processEntry(ctx, event.Entry)
 
 		case <-ctx.Done():
-			d.logger.Info("monitor loop stopping")
+			d.logger.Info("capture source stopping", "source", name)
 			return ctx.Err()
 		}
 	}
 }
 
+func (d *Daemon) processEntry(ctx context.Context, entry *domain.ClipboardEntry) {
+	// The monitor keeps running while paused, so content copied meanwhile
+	// is not picked up once capture resumes.
+	if pause := d.service.PauseState(); pause.Paused {
+		d.logger.Debug("capture paused, entry not recorded", "content_length", len(entry.Content), "until", pause.Until)
+		return
+	}
+
+	stored, err := d.service.ProcessNewEntry(ctx, entry)
+	if err != nil {
+		var sensitiveErr *service.SensitiveContentError
+		var ignoredErr *service.IgnoredContentError
+		if errors.As(err, &ignoredErr) {
+			d.logger.Debug("ignored clipboard entry", "rule", ignoredErr.Rule, "content_length", len(entry.Content))
+		} else if errors.As(err, &sensitiveErr) {
+			d.logger.Debug("skipped sensitive content", "reason", sensitiveErr.Reason, "severity", sensitiveErr.Severity, "confidence", sensitiveErr.Confidence, "vault_id", sensitiveErr.VaultId, "content_length", len(entry.Content))
+		} else {
+			d.logger.Error("failed to process entry", "error", err, "content_length", len(entry.Content))
+		}
+		return
+	}
+
+	d.logger.Info("stored clipboard entry", "id", stored.Id, "profile", stored.Profile, "source", stored.Metadata.Source, "content_length", len(stored.Content), "redactions", len(stored.Metadata.Redactions), "timestamp", stored.Timestamp)
+
+	if stored.Metadata.OriginalURL != "" && stored.Metadata.Source == clipboardSource && d.writeBackURLs {
+		d.writeBack(stored.Content)
+	}
+}
+
+// writeBack replaces the clipboard with the cleaned form of the URL just
+// stored, if the monitor can write.
+func (d *Daemon) writeBack(content string) {
+	writer, ok := d.monitor.(ClipboardWriter)
+	if !ok {
+		return
+	}
+	if err := writer.Write(content); err != nil {
+		d.logger.Error("failed to write cleaned URL to clipboard", "error", err)
+		return
+	}
+	d.logger.Debug("wrote cleaned URL to clipboard", "content_length", len(content))
+}
+
 func (d *Daemon) runRetentionLoop(ctx context.Context) error {
 	if !d.retentionEnabled {
 		d.logger.Info("retention cleanup disabled")
@@ -218,3 +337,86 @@ func (d *Daemon) runRetentionLoop(ctx context.Context) error {
 		}
 	}
 }
+
+// runExpiryLoop deletes short-TTL entries once their lifetime ends. It also
+// runs once at startup to catch entries that expired while the daemon was
+// down.
+func (d *Daemon) runExpiryLoop(ctx context.Context) error {
+	d.expireEntries(ctx)
+
+	if d.expiryInterval <= 0 {
+		d.logger.Info("short-ttl expiry disabled")
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	ticker := time.NewTicker(d.expiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.expireEntries(ctx)
+		case <-ctx.Done():
+			d.logger.Info("expiry loop stopping")
+			return ctx.Err()
+		}
+	}
+}
+
+func (d *Daemon) expireEntries(ctx context.Context) {
+	deleted, err := d.service.ExpireEntries(ctx)
+	if err != nil {
+		d.logger.Error("expiring short-ttl entries failed", "error", err)
+		return
+	}
+	if deleted > 0 {
+		d.logger.Info("expired short-ttl entries", "deleted_entries", deleted)
+	}
+}
+
+func (d *Daemon) PerformMaintenance(ctx context.Context) error {
+	report, err := d.service.RunMaintenance(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !report.IntegrityOK {
+		d.logger.Error("database integrity check failed", "problems", report.IntegrityErrors)
+	}
+
+	d.logger.Info("database maintenance completed",
+		"integrity_ok", report.IntegrityOK,
+		"full_vacuum", report.FullVacuum,
+		"size_before", report.SizeBefore,
+		"size_after", report.SizeAfter,
+		"reclaimed_bytes", report.ReclaimedBytes(),
+		"duration", report.Duration,
+	)
+
+	return nil
+}
+
+func (d *Daemon) runMaintenanceLoop(ctx context.Context) error {
+	if !d.maintenanceEnabled {
+		d.logger.Info("database maintenance disabled")
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	ticker := time.NewTicker(d.maintenanceInterval)
+	defer ticker.Stop()
+
+	d.logger.Info("database maintenance started", "interval", d.maintenanceInterval)
+	for {
+		select {
+		case <-ticker.C:
+			if err := d.PerformMaintenance(ctx); err != nil {
+				d.logger.Error("database maintenance failed", "error", err)
+			}
+		case <-ctx.Done():
+			d.logger.Info("maintenance loop stopping")
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package daemon
+
+import "time"
+
+// monitorErrorLogInterval is how often a run of monitor errors is logged.
+const monitorErrorLogInterval = time.Minute
+
+// errorLog rate-limits a run of repeated errors. The first error is logged,
+// then at most one per interval along with how many were left out.
+type errorLog struct {
+	interval time.Duration
+
+	streak     int
+	last       time.Time
+	suppressed int
+}
+
+// failed records an error and reports whether to log it, and how many
+// errors were suppressed since the last one logged.
+func (l *errorLog) failed(now time.Time) (log bool, suppressed int) {
+	l.streak++
+	if l.streak > 1 && now.Sub(l.last) < l.interval {
+		l.suppressed++
+		return false, 0
+	}
+
+	suppressed = l.suppressed
+	l.last = now
+	l.suppressed = 0
+	return true, suppressed
+}
+
+// recovered ends the run and returns how many errors it had.
+func (l *errorLog) recovered() int {
+	streak := l.streak
+	l.streak = 0
+	l.suppressed = 0
+	return streak
+}
@@ -0,0 +1,42 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestErrorLog(t *testing.T) {
+	start := time.Now()
+	l := &errorLog{interval: time.Minute}
+
+	steps := []struct {
+		name           string
+		at             time.Duration
+		recover        bool
+		wantLog        bool
+		wantSuppressed int
+		wantStreak     int
+	}{
+		{name: "first error", at: 0, wantLog: true},
+		{name: "repeat", at: time.Second},
+		{name: "repeat", at: 30 * time.Second},
+		{name: "after the interval", at: time.Minute, wantLog: true, wantSuppressed: 2},
+		{name: "repeat", at: 61 * time.Second},
+		{name: "recovered", recover: true, wantStreak: 5},
+		{name: "new run is logged at once", at: 62 * time.Second, wantLog: true},
+	}
+
+	for _, step := range steps {
+		if step.recover {
+			if streak := l.recovered(); streak != step.wantStreak {
+				t.Errorf("%s: recovered() = %d, want %d", step.name, streak, step.wantStreak)
+			}
+			continue
+		}
+
+		log, suppressed := l.failed(start.Add(step.at))
+		if log != step.wantLog || suppressed != step.wantSuppressed {
+			t.Errorf("%s: failed() = %v, %d, want %v, %d", step.name, log, suppressed, step.wantLog, step.wantSuppressed)
+		}
+	}
+}
@@ -37,7 +37,7 @@ func (sh *SignalHandler) Handle(ctx context.Context, sig os.Signal) bool {
 
 	case syscall.SIGHUP:
 		sh.daemon.logger.Info("received reload signal")
-		sh.handleReload()
+		sh.handleReload(ctx)
 		return true
 
 	case syscall.SIGUSR1:
@@ -54,16 +54,24 @@ func (sh *SignalHandler) Handle(ctx context.Context, sig os.Signal) bool {
 	return true
 }
 
-func (sh *SignalHandler) handleReload() {
+func (sh *SignalHandler) handleReload(ctx context.Context) {
+	rules, err := sh.daemon.service.ReloadRules(ctx)
+	if err != nil {
+		sh.daemon.logger.Error("failed to reload rules, keeping previous rules", "error", err)
+	} else {
+		sh.daemon.logger.Info("reloaded sensitive-content rules", "rules", len(rules))
+	}
 
 	sh.daemon.logger.Info("current configuration",
-		"poll_interval", sh.daemon.pollInterval,
+		"monitor", sh.daemon.monitor.Backend(),
 		"retention_enabled", sh.daemon.retentionEnabled,
 		"retention_max_age", sh.daemon.retentionMaxAge,
 		"retention_interval", sh.daemon.retentionInterval,
+		"maintenance_enabled", sh.daemon.maintenanceEnabled,
+		"maintenance_interval", sh.daemon.maintenanceInterval,
 	)
 
-	sh.daemon.logger.Info("note: full config reload requires daemon restart")
+	sh.daemon.logger.Info("note: only rules are reloaded, other config changes require daemon restart")
 }
 
 func (sh *SignalHandler) handleManualRetention(ctx context.Context) {
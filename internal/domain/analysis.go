@@ -0,0 +1,121 @@
+package domain
+
+import "time"
+
+type Severity string
+
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// Rank orders severities so that findings can be compared; unknown values
+// rank lowest.
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityLow:
+		return 1
+	case SeverityMedium:
+		return 2
+	case SeverityHigh:
+		return 3
+	case SeverityCritical:
+		return 4
+	}
+	return 0
+}
+
+type Confidence string
+
+const (
+	ConfidenceLow    Confidence = "low"
+	ConfidenceMedium Confidence = "medium"
+	ConfidenceHigh   Confidence = "high"
+)
+
+func (c Confidence) Rank() int {
+	switch c {
+	case ConfidenceLow:
+		return 1
+	case ConfidenceMedium:
+		return 2
+	case ConfidenceHigh:
+		return 3
+	}
+	return 0
+}
+
+type RuleAction string
+
+const (
+	ActionBlock    RuleAction = "block"
+	ActionRedact   RuleAction = "redact"
+	ActionAllow    RuleAction = "allow"
+	ActionShortTTL RuleAction = "short_ttl" // store, but expire after Analysis.TTL
+)
+
+// Rule describes a named sensitive-content matcher. Pattern rules set
+// exactly one of Pattern or Keywords; built-in detectors set neither.
+type Rule struct {
+	Name     string
+	Pattern  string
+	Keywords []string
+	Severity Severity
+	Action   RuleAction
+	Builtin  bool
+	Detector bool
+}
+
+// Match is a span of content, as byte offsets, matched by a rule.
+type Match struct {
+	Analyzer   string
+	Rule       string
+	Severity   Severity
+	Confidence Confidence
+	Action     RuleAction
+	Start      int
+	End        int
+}
+
+type FindingKind string
+
+const (
+	FindingType     FindingKind = "type"
+	FindingLanguage FindingKind = "language"
+	FindingTag      FindingKind = "tag"
+	FindingDomain   FindingKind = "domain" // host of a web URL
+	FindingURL      FindingKind = "url"    // the URL cleaned of tracking parameters
+	FindingError    FindingKind = "error"  // the analyzer failed or timed out
+)
+
+// Finding is one observation about an entry other than a sensitive span.
+// Score ranks competing type and language findings, from 0 to 1.
+type Finding struct {
+	Analyzer string
+	Kind     FindingKind
+	Value    string
+	Score    float64
+}
+
+// Analysis is the analyzer's verdict on one entry. Reason names the rule
+// that made the entry sensitive or, failing that, the one that limited its
+// lifetime to TTL.
+//
+// Type, Language, Tags, Domain and CleanURL summarise Findings; Matches lists sensitive spans.
+// Both record the analyzer that produced them.
+type Analysis struct {
+	Type        ContentType
+	Language    string
+	Tags        []string
+	Domain      string
+	CleanURL    string
+	IsSensitive bool
+	Reason      string
+	Severity    Severity
+	Confidence  Confidence
+	TTL         time.Duration // zero keeps the entry until retention removes it
+	Findings    []Finding
+	Matches     []Match
+}
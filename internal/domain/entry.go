@@ -1,11 +1,68 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type ClipboardEntry struct {
 	Id        string
 	Content   string
+	Type      ContentType
+	Language  string // programming language of code entries, if known
+	Domain    string // host of URL entries
+	Profile   string
+	Metadata  EntryMetadata
 	Timestamp time.Time
+	ExpiresAt time.Time // zero unless a short-TTL policy applied
+	SourceApp string    // application that owned the clipboard, if the monitor knows; not stored
+
+	UseCount   int       // times the entry was copied back to the clipboard
+	LastUsedAt time.Time // zero until the first copy
+}
+
+// HistoryFilter narrows a history listing. Empty fields match every entry.
+type HistoryFilter struct {
+	Language string
+	Domain   string // also matches subdomains
+}
+
+// EntryMetadata holds per-entry details that do not need their own column.
+// It is persisted as JSON.
+type EntryMetadata struct {
+	Redactions  []Redaction `json:"redactions,omitempty"`
+	OriginalURL string      `json:"original_url,omitempty"` // as copied, when the stored URL was cleaned
+	Selection   Selection   `json:"selection,omitempty"`    // empty for entries captured before selections were recorded
+	Source      string      `json:"source,omitempty"`       // clipboard, fifo, tmux or file:<path>; empty for entries added through the API
+}
+
+// Selection names the X11/Wayland selection an entry was captured from.
+type Selection string
+
+const (
+	SelectionClipboard Selection = "clipboard"
+	SelectionPrimary   Selection = "primary"   // select-to-copy
+	SelectionSecondary Selection = "secondary" // X11 only
+)
+
+// ParseSelection accepts a selection name in any case. An empty name means
+// the clipboard.
+func ParseSelection(name string) (Selection, bool) {
+	switch s := Selection(strings.ToLower(strings.TrimSpace(name))); s {
+	case "":
+		return SelectionClipboard, true
+	case SelectionClipboard, SelectionPrimary, SelectionSecondary:
+		return s, true
+	}
+	return "", false
+}
+
+// Redaction marks a placeholder in the stored content, as byte offsets,
+// that replaced a span matched by Rule.
+type Redaction struct {
+	Rule  string `json:"rule"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
 }
 
 type ContentType string
@@ -15,11 +72,18 @@ const (
 	ContentTypeURL      ContentType = "url"
 	ContentTypeCode     ContentType = "code"
 	ContentTypeFilePath ContentType = "filepath"
+	ContentTypeJSON     ContentType = "json"
+	ContentTypeYAML     ContentType = "yaml"
+	ContentTypeXML      ContentType = "xml"
+	ContentTypeEmail    ContentType = "email"
+	ContentTypeIP       ContentType = "ip" // IPv4, IPv6 or CIDR
+	ContentTypeUUID     ContentType = "uuid"
+	ContentTypeColor    ContentType = "color"
+	ContentTypeSQL      ContentType = "sql"
+	ContentTypeShell    ContentType = "shell"
+	ContentTypeBase64   ContentType = "base64"
+	ContentTypeMarkdown ContentType = "markdown"
+	ContentTypeNumber   ContentType = "number"
+	ContentTypeDate     ContentType = "date"
 	ContentTypeUknown   ContentType = "unknown"
 )
-
-type Analysis struct {
-	Type        ContentType
-	IsSensitive bool
-	Reason      string
-}
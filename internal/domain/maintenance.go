@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// MaintenanceReport summarises one run of database housekeeping.
+type MaintenanceReport struct {
+	StartedAt       time.Time
+	Duration        time.Duration
+	IntegrityOK     bool
+	IntegrityErrors []string
+	FullVacuum      bool // the database had to be rebuilt to enable incremental vacuum
+	SizeBefore      int64
+	SizeAfter       int64
+}
+
+func (r *MaintenanceReport) ReclaimedBytes() int64 {
+	return max(r.SizeBefore-r.SizeAfter, 0)
+}
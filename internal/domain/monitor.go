@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// MonitorStatus describes how one selection is being watched. Interval is
+// the current delay between polls and is zero while change events are used.
+type MonitorStatus struct {
+	Selection    Selection
+	Backend      string
+	Mode         string // events or poll
+	Interval     time.Duration
+	ErrorStreak  int // consecutive failed reads
+	LastError    string
+	LastErrorAt  time.Time
+	LastChangeAt time.Time
+}
+
+// PauseState says whether capture is paused. A zero Until means the pause
+// lasts until capture is resumed.
+type PauseState struct {
+	Paused bool
+	Since  time.Time
+	Until  time.Time
+}
+
+// At returns the state at now, with an expired pause lifted.
+func (p PauseState) At(now time.Time) PauseState {
+	if p.Paused && !p.Until.IsZero() && !now.Before(p.Until) {
+		return PauseState{}
+	}
+	return p
+}
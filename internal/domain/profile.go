@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+const DefaultProfile = "default"
+
+// Profile is an isolated clipboard history with its own retention policy.
+type Profile struct {
+	Name            string
+	RetentionMaxAge time.Duration // zero uses the daemon-wide retention
+	CreatedAt       time.Time
+	Active          bool
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// StatsQuery describes the aggregate view requested from storage.
+type StatsQuery struct {
+	Since   time.Time     // start of the activity window
+	Until   time.Time     // end of the activity window
+	Bucket  time.Duration // width of one activity bucket
+	Largest int           // number of largest entries to return
+}
+
+type EntrySize struct {
+	Id        string
+	Type      ContentType
+	Size      int
+	Timestamp time.Time
+}
+
+type ActivityBucket struct {
+	Start time.Time
+	Count int
+}
+
+type HistoryStats struct {
+	TotalEntries  int
+	TotalBytes    int64
+	CountsByType  map[ContentType]int
+	Largest       []EntrySize
+	Activity      []ActivityBucket
+	Oldest        time.Time
+	Newest        time.Time
+	DatabaseBytes int64
+}
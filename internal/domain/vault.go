@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// VaultEntry describes a sensitive entry held in memory for a short time.
+// It never carries the content itself.
+type VaultEntry struct {
+	Id        string
+	Preview   string
+	Length    int
+	Type      ContentType
+	Reason    string
+	Severity  Severity
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
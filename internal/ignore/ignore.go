@@ -0,0 +1,99 @@
+// Package ignore decides which clipboard captures are never recorded, before
+// they reach the analyzer.
+package ignore
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// Rule names reported by Match. Pattern and source-app rules add the
+// pattern or application after a colon.
+const (
+	RuleWhitespace = "whitespace"
+	RuleMinLength  = "min_length"
+	RuleMaxLength  = "max_length"
+	RulePattern    = "pattern"
+	RuleSourceApp  = "source_app"
+)
+
+type pattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+type Rules struct {
+	whitespace bool
+	minLength  int
+	maxLength  int
+	patterns   []pattern
+	sourceApps map[string]string // lowercased name -> configured name
+}
+
+func New(cfg config.IgnoreConfig) (*Rules, error) {
+	if cfg.MinLength < 0 || cfg.MaxLength < 0 {
+		return nil, fmt.Errorf("ignore lengths cannot be negative")
+	}
+	if cfg.MaxLength > 0 && cfg.MaxLength < cfg.MinLength {
+		return nil, fmt.Errorf("ignore max length %d is below min length %d", cfg.MaxLength, cfg.MinLength)
+	}
+
+	r := &Rules{
+		whitespace: cfg.Whitespace,
+		minLength:  cfg.MinLength,
+		maxLength:  cfg.MaxLength,
+		sourceApps: make(map[string]string, len(cfg.SourceApps)),
+	}
+
+	for _, expr := range cfg.Patterns {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %w", expr, err)
+		}
+		r.patterns = append(r.patterns, pattern{name: RulePattern + ":" + expr, re: re})
+	}
+
+	for _, app := range cfg.SourceApps {
+		if app = strings.TrimSpace(app); app != "" {
+			r.sourceApps[strings.ToLower(app)] = app
+		}
+	}
+
+	return r, nil
+}
+
+// Match names the first rule that ignores entry, or returns "" to record
+// it. Lengths count characters, not bytes.
+func (r *Rules) Match(entry *domain.ClipboardEntry) string {
+	if entry.SourceApp != "" {
+		if app, ok := r.sourceApps[strings.ToLower(entry.SourceApp)]; ok {
+			return RuleSourceApp + ":" + app
+		}
+	}
+
+	content := entry.Content
+	if r.whitespace && strings.TrimSpace(content) == "" {
+		return RuleWhitespace
+	}
+
+	length := utf8.RuneCountInString(content)
+	if length < r.minLength {
+		return RuleMinLength
+	}
+	if r.maxLength > 0 && length > r.maxLength {
+		return RuleMaxLength
+	}
+
+	for _, p := range r.patterns {
+		if p.re.MatchString(content) {
+			return p.name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,67 @@
+package ignore
+
+import (
+	"testing"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+func TestMatch(t *testing.T) {
+	rules, err := New(config.IgnoreConfig{
+		Patterns:   []string{`^\d{6}$`, `(?i)^otp:`},
+		MinLength:  3,
+		MaxLength:  20,
+		Whitespace: true,
+		SourceApps: []string{"KeePassXC", " 1Password "},
+	})
+	if err != nil {
+		t.Fatalf("failed to create rules: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		content   string
+		sourceApp string
+		want      string
+	}{
+		{name: "recorded", content: "hello world", want: ""},
+		{name: "one-time code", content: "492817", want: "pattern:^\\d{6}$"},
+		{name: "second pattern", content: "OTP: 1234", want: "pattern:(?i)^otp:"},
+		{name: "whitespace only", content: " \n\t ", want: RuleWhitespace},
+		{name: "too short", content: "ab", want: RuleMinLength},
+		{name: "length counts characters", content: "héé", want: ""},
+		{name: "too long", content: "this line is longer than twenty", want: RuleMaxLength},
+		{name: "password manager", content: "hunter2", sourceApp: "keepassxc", want: "source_app:KeePassXC"},
+		{name: "trimmed app name", content: "hunter2", sourceApp: "1Password", want: "source_app:1Password"},
+		{name: "other app", content: "hunter2", sourceApp: "firefox", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rules.Match(&domain.ClipboardEntry{Content: tt.content, SourceApp: tt.sourceApp})
+			if got != tt.want {
+				t.Errorf("Match(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.IgnoreConfig
+	}{
+		{name: "invalid pattern", cfg: config.IgnoreConfig{Patterns: []string{"("}}},
+		{name: "negative length", cfg: config.IgnoreConfig{MinLength: -1}},
+		{name: "max below min", cfg: config.IgnoreConfig{MinLength: 10, MaxLength: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.cfg); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
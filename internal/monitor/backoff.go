@@ -0,0 +1,58 @@
+package monitor
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// idleGrowth is how much longer each poll waits than the last while the
+// clipboard stays unchanged.
+const idleGrowth = 1.25
+
+// Backoff sets how often a polling monitor reads the clipboard. It polls
+// every Min right after a change, slows down gradually to Max while nothing
+// changes, and after consecutive errors waits exponentially longer, up to
+// ErrorMax, with jitter. A Max or ErrorMax below Min means Min.
+type Backoff struct {
+	Min      time.Duration
+	Max      time.Duration
+	ErrorMax time.Duration
+}
+
+// scheduler holds the state of one monitor's Backoff.
+type scheduler struct {
+	Backoff
+	interval time.Duration
+	jitter   func() float64 // in [0, 1)
+}
+
+func (b Backoff) scheduler() *scheduler {
+	b.Max = max(b.Max, b.Min)
+	b.ErrorMax = max(b.ErrorMax, b.Min)
+	return &scheduler{Backoff: b, interval: b.Min, jitter: rand.Float64}
+}
+
+// next returns how long to wait after a poll. errorStreak counts the
+// consecutive failed reads, including this one.
+func (s *scheduler) next(changed bool, errorStreak int) time.Duration {
+	if errorStreak > 0 {
+		// Poll quickly again once the clipboard is back.
+		s.interval = s.Min
+
+		delay := s.Min
+		for i := 1; i < errorStreak && delay < s.ErrorMax; i++ {
+			delay *= 2
+		}
+		delay = min(delay, s.ErrorMax)
+		// Half fixed, half random, so that monitors failing together do
+		// not retry in step.
+		return delay/2 + time.Duration(s.jitter()*float64(delay/2))
+	}
+
+	if changed {
+		s.interval = s.Min
+	} else {
+		s.interval = min(time.Duration(float64(s.interval)*idleGrowth), s.Max)
+	}
+	return s.interval
+}
@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	s := Backoff{Min: time.Second, Max: 2 * time.Second, ErrorMax: 10 * time.Second}.scheduler()
+	s.jitter = func() float64 { return 0.5 }
+
+	steps := []struct {
+		name        string
+		changed     bool
+		errorStreak int
+		want        time.Duration
+	}{
+		{name: "idle", want: 1250 * time.Millisecond},
+		{name: "idle", want: 1562500 * time.Microsecond},
+		{name: "idle", want: 1953125 * time.Microsecond},
+		{name: "idle reaches max", want: 2 * time.Second},
+		{name: "idle stays at max", want: 2 * time.Second},
+		{name: "change resets", changed: true, want: time.Second},
+		{name: "first error", errorStreak: 1, want: 750 * time.Millisecond},
+		{name: "second error", errorStreak: 2, want: 1500 * time.Millisecond},
+		{name: "third error", errorStreak: 3, want: 3 * time.Second},
+		{name: "fifth error is capped", errorStreak: 5, want: 7500 * time.Millisecond},
+		{name: "many errors stay capped", errorStreak: 100, want: 7500 * time.Millisecond},
+		{name: "recovered polls fast", want: 1250 * time.Millisecond},
+	}
+
+	for _, step := range steps {
+		if got := s.next(step.changed, step.errorStreak); got != step.want {
+			t.Errorf("%s: next() = %s, want %s", step.name, got, step.want)
+		}
+	}
+}
+
+func TestBackoff_Defaults(t *testing.T) {
+	s := Backoff{Min: time.Second}.scheduler()
+	s.jitter = func() float64 { return 0 }
+
+	if got := s.next(false, 0); got != time.Second {
+		t.Errorf("idle next() = %s, want a fixed 1s", got)
+	}
+	if got := s.next(false, 3); got != 500*time.Millisecond {
+		t.Errorf("error next() = %s, want 500ms", got)
+	}
+}
+
+func TestPollingMonitor_Status(t *testing.T) {
+	clipboard := NewFakeClipboard()
+	pm := NewPollingMonitor(clipboard, Backoff{Min: time.Hour})
+
+	if status := pm.Status(); status.Mode != "poll" || status.Interval != time.Hour {
+		t.Fatalf("Status() = %+v, want polling every hour", status)
+	}
+
+	clipboard.FailReads(errors.New("no display"))
+	pm.Check()
+	pm.Check()
+	if status := pm.Status(); status.ErrorStreak != 2 || status.LastError != "no display" || status.LastErrorAt.IsZero() {
+		t.Errorf("Status() = %+v, want two failed reads", status)
+	}
+
+	clipboard.FailReads(nil)
+	clipboard.Set("back")
+	pm.Check()
+	if status := pm.Status(); status.ErrorStreak != 0 || status.LastChangeAt.IsZero() {
+		t.Errorf("Status() = %+v, want the streak reset and the change recorded", status)
+	}
+}
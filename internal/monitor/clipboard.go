@@ -0,0 +1,132 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/atotto/clipboard"
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// Clipboard reads and writes the system clipboard.
+type Clipboard interface {
+	Read() (string, error)
+	Write(content string) error
+}
+
+// SystemClipboard uses xclip, xsel or wl-clipboard on Linux and the native
+// APIs elsewhere.
+type SystemClipboard struct{}
+
+func (SystemClipboard) Read() (string, error) {
+	return clipboard.ReadAll()
+}
+
+func (SystemClipboard) Write(content string) error {
+	return clipboard.WriteAll(content)
+}
+
+// NewClipboard returns the backend named by cfg.Clipboard for a selection.
+// "auto" uses SystemClipboard for the clipboard and looks for wl-clipboard,
+// xclip or xsel for the other selections. xclip, xsel, wl-clipboard and tmux
+// use that tool. "command" runs cfg.ReadCommand and cfg.WriteCommand with
+// sh -c, after replacing {selection} with the selection name.
+func NewClipboard(cfg config.MonitorConfig, selection domain.Selection) (Clipboard, error) {
+	switch cfg.Clipboard {
+	case "", "auto":
+		if selection == domain.SelectionClipboard {
+			return SystemClipboard{}, nil
+		}
+		clipboard, err := NewSelectionClipboard(selection)
+		if err != nil {
+			return nil, err
+		}
+		return clipboard, nil
+
+	case "command":
+		if cfg.ReadCommand == "" || cfg.WriteCommand == "" {
+			return nil, fmt.Errorf("the command clipboard backend needs both a read and a write command")
+		}
+		shell := func(command string) []string {
+			return []string{"sh", "-c", strings.ReplaceAll(command, "{selection}", string(selection))}
+		}
+		return NewCommandClipboard(shell(cfg.ReadCommand), shell(cfg.WriteCommand)), nil
+
+	case "xclip", "xsel", "wl-clipboard", "tmux":
+		read, write, ok := selectionCommands(cfg.Clipboard, selection)
+		if !ok {
+			return nil, fmt.Errorf("the %s clipboard backend cannot access the %s selection", cfg.Clipboard, selection)
+		}
+		return NewCommandClipboard(read, write), nil
+	}
+
+	return nil, fmt.Errorf("unknown clipboard backend %q (want auto, xclip, xsel, wl-clipboard, tmux or command)", cfg.Clipboard)
+}
+
+// FakeClipboard is an in-memory Clipboard for tests. Set simulates another
+// application copying; Writes records what was written through Write.
+type FakeClipboard struct {
+	mu       sync.Mutex
+	content  string
+	writes   []string
+	readErr  error
+	writeErr error
+}
+
+func NewFakeClipboard() *FakeClipboard {
+	return &FakeClipboard{}
+}
+
+func (f *FakeClipboard) Read() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.readErr != nil {
+		return "", f.readErr
+	}
+	return f.content, nil
+}
+
+func (f *FakeClipboard) Write(content string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.writeErr != nil {
+		return f.writeErr
+	}
+	f.content = content
+	f.writes = append(f.writes, content)
+	return nil
+}
+
+func (f *FakeClipboard) Set(content string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.content = content
+}
+
+// FailReads makes Read return err until it is called again with nil.
+func (f *FakeClipboard) FailReads(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.readErr = err
+}
+
+// FailWrites makes Write return err until it is called again with nil.
+func (f *FakeClipboard) FailWrites(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.writeErr = err
+}
+
+func (f *FakeClipboard) Writes() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.writes...)
+}
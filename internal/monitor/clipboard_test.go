@@ -0,0 +1,218 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// fakeClipboardScript keeps each selection in a file next to the script,
+// like a command-line clipboard tool would keep it in the display server.
+const fakeClipboardScript = `#!/bin/sh
+store="$(dirname "$0")/$2.txt"
+case "$1" in
+read)
+	[ -f "$store" ] || { echo "nothing copied" >&2; exit 1; }
+	cat "$store" ;;
+write)
+	cat > "$store" ;;
+esac
+`
+
+func newFakeClipboardScript(t *testing.T) (script, dir string) {
+	t.Helper()
+
+	dir = t.TempDir()
+	script = filepath.Join(dir, "fakeclip")
+	if err := os.WriteFile(script, []byte(fakeClipboardScript), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return script, dir
+}
+
+func TestNewClipboard_Command(t *testing.T) {
+	script, dir := newFakeClipboardScript(t)
+	cfg := config.MonitorConfig{
+		Clipboard:    "command",
+		ReadCommand:  script + " read {selection}",
+		WriteCommand: script + " write {selection}",
+	}
+
+	clipboard, err := NewClipboard(cfg, domain.SelectionClipboard)
+	if err != nil {
+		t.Fatalf("NewClipboard() error = %v", err)
+	}
+	primary, err := NewClipboard(cfg, domain.SelectionPrimary)
+	if err != nil {
+		t.Fatalf("NewClipboard() error = %v", err)
+	}
+
+	if _, err := clipboard.Read(); err == nil || !strings.Contains(err.Error(), "nothing copied") {
+		t.Errorf("Read() error = %v, want the command's stderr", err)
+	}
+
+	if err := clipboard.Write("hello\nworld"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if got, err := clipboard.Read(); err != nil || got != "hello\nworld" {
+		t.Errorf("Read() = %q, %v, want the written content", got, err)
+	}
+	if err := primary.Write("selected"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "primary.txt")); string(got) != "selected" {
+		t.Errorf("primary selection holds %q, want %q", got, "selected")
+	}
+
+	// A polling monitor over the command backend sees copies made by
+	// other programs but not its own writes.
+	pm := NewPollingMonitor(clipboard, Backoff{Min: time.Hour})
+	if err := os.WriteFile(filepath.Join(dir, "clipboard.txt"), []byte("from elsewhere"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if entry, changed, err := pm.Check(); err != nil || !changed || entry.Content != "from elsewhere" {
+		t.Fatalf("Check() = %+v, %v, %v, want the external copy", entry, changed, err)
+	}
+	if err := pm.Write("from history"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, changed, err := pm.Check(); err != nil || changed {
+		t.Errorf("Check() changed = %v, %v after our own write, want no change", changed, err)
+	}
+}
+
+func TestNewClipboard(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.MonitorConfig
+		selection domain.Selection
+		wantErr   bool
+	}{
+		{name: "auto clipboard", cfg: config.MonitorConfig{Clipboard: "auto"}, selection: domain.SelectionClipboard},
+		{name: "xclip", cfg: config.MonitorConfig{Clipboard: "xclip"}, selection: domain.SelectionSecondary},
+		{name: "xsel", cfg: config.MonitorConfig{Clipboard: "xsel"}, selection: domain.SelectionPrimary},
+		{name: "wl-clipboard", cfg: config.MonitorConfig{Clipboard: "wl-clipboard"}, selection: domain.SelectionPrimary},
+		{name: "wl-clipboard has no secondary", cfg: config.MonitorConfig{Clipboard: "wl-clipboard"}, selection: domain.SelectionSecondary, wantErr: true},
+		{name: "tmux", cfg: config.MonitorConfig{Clipboard: "tmux"}, selection: domain.SelectionClipboard},
+		{name: "tmux has no primary", cfg: config.MonitorConfig{Clipboard: "tmux"}, selection: domain.SelectionPrimary, wantErr: true},
+		{name: "command without write", cfg: config.MonitorConfig{Clipboard: "command", ReadCommand: "cat"}, selection: domain.SelectionClipboard, wantErr: true},
+		{name: "unknown", cfg: config.MonitorConfig{Clipboard: "pbcopy"}, selection: domain.SelectionClipboard, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClipboard(tt.cfg, tt.selection)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewClipboard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNew_CommandClipboardPolls(t *testing.T) {
+	script, _ := newFakeClipboardScript(t)
+	cfg := config.MonitorConfig{
+		Backend:      "auto",
+		Clipboard:    "command",
+		ReadCommand:  script + " read {selection}",
+		WriteCommand: script + " write {selection}",
+		PollInterval: time.Second,
+	}
+	t.Setenv("WAYLAND_DISPLAY", "wayland-0")
+
+	clipboard, err := NewClipboard(cfg, domain.SelectionClipboard)
+	if err != nil {
+		t.Fatalf("NewClipboard() error = %v", err)
+	}
+	m, err := New(cfg, clipboard)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if m.Backend() != "poll" {
+		t.Errorf("Backend() = %q, want poll for a command clipboard", m.Backend())
+	}
+
+	if err := m.WriteSelection(domain.SelectionPrimary, "unmonitored"); err != nil {
+		t.Errorf("WriteSelection() error = %v, want the command backend used for it too", err)
+	}
+}
+
+func TestCommandClipboard_WriteForks(t *testing.T) {
+	dir := t.TempDir()
+	store := filepath.Join(dir, "clipboard.txt")
+
+	// Like xclip, the write command leaves a child behind holding the
+	// selection, with stderr inherited.
+	clipboard := NewCommandClipboard(
+		[]string{"cat", store},
+		[]string{"sh", "-c", `cat > "$0"; sleep 3 &`, store},
+	)
+	pm := NewPollingMonitor(clipboard, Backoff{Min: time.Hour})
+
+	done := make(chan error, 1)
+	start := time.Now()
+	go func() { done <- pm.Write("copied") }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Write() waited for the forked child")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Write() took %v", elapsed)
+	}
+	if _, changed, err := pm.Check(); err != nil || changed {
+		t.Errorf("Check() changed = %v, %v after our own write, want no change", changed, err)
+	}
+
+	failing := NewCommandClipboard(nil, []string{"sh", "-c", "echo no display >&2; exit 1"})
+	if err := failing.Write("x"); err == nil || !strings.Contains(err.Error(), "no display") {
+		t.Errorf("Write() error = %v, want the command's stderr", err)
+	}
+}
+
+func TestTracker_WriteDoesNotBlockCheck(t *testing.T) {
+	dir := t.TempDir()
+	store := filepath.Join(dir, "clipboard.txt")
+	if err := os.WriteFile(store, []byte("before"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	clipboard := NewCommandClipboard(
+		[]string{"cat", store},
+		[]string{"sh", "-c", `cat > "$0"; sleep 1`, store},
+	)
+	pm := NewPollingMonitor(clipboard, Backoff{Min: time.Hour})
+	if _, _, err := pm.Check(); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- pm.Write("copied") }()
+
+	// The content lands before the command exits; checking meanwhile must
+	// neither wait for the write nor report it as new.
+	deadline := time.Now().Add(900 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if _, changed, err := pm.Check(); err != nil || changed {
+			t.Fatalf("Check() changed = %v, %v during our own write, want no change", changed, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("Write() = %v, want it still running while checks ran", err)
+	default:
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package monitor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// Notifier reports that the clipboard changed, without its content. Watch
+// calls changed for every change until ctx is done, and returns an error if
+// it cannot keep watching.
+type Notifier interface {
+	Name() string
+	Watch(ctx context.Context, changed func()) error
+}
+
+// EventMonitor reads the clipboard only when its notifier reports a change.
+// If the notifier fails, it reports the error once and falls back to
+// polling with fallback.
+type EventMonitor struct {
+	tracker
+	notifier Notifier
+	fallback Backoff
+}
+
+func NewEventMonitor(clipboard Clipboard, notifier Notifier, fallback Backoff) *EventMonitor {
+	em := &EventMonitor{
+		tracker:  tracker{clipboard: clipboard},
+		notifier: notifier,
+		fallback: fallback,
+	}
+	em.status = domain.MonitorStatus{Backend: notifier.Name(), Mode: "events"}
+	return em
+}
+
+func (em *EventMonitor) Backend() string {
+	return em.notifier.Name()
+}
+
+func (em *EventMonitor) Status() domain.MonitorStatus {
+	return em.currentStatus()
+}
+
+func (em *EventMonitor) Events(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+
+	// A burst of notifications while a read is in progress needs only one
+	// more read, so pending changes are coalesced.
+	changed := make(chan struct{}, 1)
+	failed := make(chan error, 1)
+	go func() {
+		failed <- em.notifier.Watch(ctx, func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+	}()
+
+	go func() {
+		defer close(events)
+
+		// Pick up whatever was on the clipboard before the first
+		// notification.
+		if _, ok := em.emit(ctx, events); !ok {
+			return
+		}
+
+		for {
+			select {
+			case <-changed:
+				if _, ok := em.emit(ctx, events); !ok {
+					return
+				}
+			case err := <-failed:
+				if ctx.Err() != nil {
+					return
+				}
+				if err == nil {
+					err = fmt.Errorf("stopped")
+				}
+				select {
+				case events <- Event{Err: fmt.Errorf("%s clipboard events failed, falling back to polling every %s: %w", em.notifier.Name(), em.fallback.Min, err)}:
+				case <-ctx.Done():
+					return
+				}
+				em.poll(ctx, em.fallback, events)
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// CommandNotifier runs an external program that signals clipboard changes.
+// A watching command prints a line per change and keeps running; a one-shot
+// command exits after each change and is started again.
+type CommandNotifier struct {
+	name    string
+	command []string
+	oneShot bool
+}
+
+// NewWaylandNotifier uses wl-paste --watch, which runs a command on every
+// change to the Wayland clipboard or primary selection.
+func NewWaylandNotifier(selection domain.Selection) *CommandNotifier {
+	command := []string{"wl-paste", "--watch", "echo"}
+	if selection == domain.SelectionPrimary {
+		command = []string{"wl-paste", "--primary", "--watch", "echo"}
+	}
+	return NewCommandNotifier("wayland", command, false)
+}
+
+// NewXFixesNotifier uses clipnotify, which waits for an X11 XFixes
+// selection notification and exits.
+func NewXFixesNotifier(selection domain.Selection) *CommandNotifier {
+	return NewCommandNotifier("x11", []string{"clipnotify", "-s", string(selection)}, true)
+}
+
+func NewCommandNotifier(name string, command []string, oneShot bool) *CommandNotifier {
+	return &CommandNotifier{name: name, command: command, oneShot: oneShot}
+}
+
+func (n *CommandNotifier) Name() string {
+	return n.name
+}
+
+func (n *CommandNotifier) Watch(ctx context.Context, changed func()) error {
+	if n.oneShot {
+		return n.watchOneShot(ctx, changed)
+	}
+
+	cmd := exec.CommandContext(ctx, n.command[0], n.command[1:]...)
+	// Children of the command can keep stdout open after it is killed.
+	cmd.WaitDelay = time.Second
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting %s: %w", n.command[0], err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		changed()
+	}
+
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", strings.Join(n.command, " "), err)
+	}
+	return fmt.Errorf("%s exited", strings.Join(n.command, " "))
+}
+
+func (n *CommandNotifier) watchOneShot(ctx context.Context, changed func()) error {
+	for {
+		err := exec.CommandContext(ctx, n.command[0], n.command[1:]...).Run()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(n.command, " "), err)
+		}
+		changed()
+	}
+}
+
+// FakeNotifier is a Notifier for tests. Notify reports a change and Fail
+// makes Watch return err; both block until Watch is running.
+type FakeNotifier struct {
+	changes chan struct{}
+	errs    chan error
+}
+
+func NewFakeNotifier() *FakeNotifier {
+	return &FakeNotifier{
+		changes: make(chan struct{}),
+		errs:    make(chan error),
+	}
+}
+
+func (f *FakeNotifier) Name() string {
+	return "fake"
+}
+
+func (f *FakeNotifier) Notify() {
+	f.changes <- struct{}{}
+}
+
+func (f *FakeNotifier) Fail(err error) {
+	f.errs <- err
+}
+
+func (f *FakeNotifier) Watch(ctx context.Context, changed func()) error {
+	for {
+		select {
+		case <-f.changes:
+			changed()
+		case err := <-f.errs:
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+)
+
+func receive(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("events closed early")
+		}
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return Event{}
+}
+
+func TestEventMonitor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clipboard := NewFakeClipboard()
+	clipboard.Set("before start")
+	notifier := NewFakeNotifier()
+	em := NewEventMonitor(clipboard, notifier, Backoff{Min: 10 * time.Millisecond})
+
+	events := em.Events(ctx)
+
+	if event := receive(t, events); event.Entry == nil || event.Entry.Content != "before start" {
+		t.Fatalf("expected the initial content, got %+v", event)
+	}
+
+	clipboard.Set("copied")
+	notifier.Notify()
+	if event := receive(t, events); event.Entry == nil || event.Entry.Content != "copied" {
+		t.Fatalf("expected the copy, got %+v", event)
+	}
+
+	// Neither our own write nor a notification without a change produces
+	// an event, so the next one is the following copy.
+	if err := em.Write("from history"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	notifier.Notify()
+	notifier.Notify()
+	clipboard.Set("next")
+	notifier.Notify()
+	if event := receive(t, events); event.Entry == nil || event.Entry.Content != "next" {
+		t.Fatalf("expected the next copy, got %+v", event)
+	}
+
+	clipboard.FailReads(errors.New("no display"))
+	notifier.Notify()
+	if event := receive(t, events); event.Err == nil {
+		t.Fatalf("expected the read error, got %+v", event)
+	}
+	clipboard.FailReads(nil)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestEventMonitor_FallsBackToPolling(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clipboard := NewFakeClipboard()
+	notifier := NewFakeNotifier()
+	em := NewEventMonitor(clipboard, notifier, Backoff{Min: 10 * time.Millisecond})
+
+	events := em.Events(ctx)
+
+	notifier.Fail(errors.New("wl-paste: exit status 1"))
+	if event := receive(t, events); event.Err == nil {
+		t.Fatalf("expected the notifier failure to be reported, got %+v", event)
+	}
+
+	clipboard.Set("polled")
+	if event := receive(t, events); event.Entry == nil || event.Entry.Content != "polled" {
+		t.Fatalf("expected polling to pick up the copy, got %+v", event)
+	}
+	if status := em.Status(); status.Mode != "poll" || status.Backend != "fake" {
+		t.Errorf("Status() = %+v, want fake polling after the fallback", status)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestCommandNotifier(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     []string
+		oneShot     bool
+		wantChanges int
+		wantErr     bool
+	}{
+		{
+			name:        "watching command",
+			command:     []string{"sh", "-c", "echo; echo; exec sleep 5"},
+			wantChanges: 2,
+		},
+		{
+			name:        "watching command exits",
+			command:     []string{"sh", "-c", "echo"},
+			wantChanges: 1,
+			wantErr:     true,
+		},
+		{
+			name:        "one-shot command",
+			command:     []string{"sh", "-c", "exit 0"},
+			oneShot:     true,
+			wantChanges: 3,
+		},
+		{
+			name:    "one-shot command fails",
+			command: []string{"sh", "-c", "exit 1"},
+			oneShot: true,
+			wantErr: true,
+		},
+		{
+			name:    "missing command",
+			command: []string{"clipd-no-such-command"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			changes := 0
+			err := NewCommandNotifier("test", tt.command, tt.oneShot).Watch(ctx, func() {
+				changes++
+				if changes == tt.wantChanges && !tt.wantErr {
+					cancel()
+				}
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Watch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if changes != tt.wantChanges {
+				t.Errorf("Watch() reported %d changes, want %d", changes, tt.wantChanges)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		backend string
+		want    string
+		wantErr bool
+	}{
+		{backend: "poll", want: "poll"},
+		{backend: "wayland", want: "wayland"},
+		{backend: "x11", want: "x11"},
+		{backend: "clipboard", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			m, err := New(config.MonitorConfig{Backend: tt.backend, PollInterval: time.Second}, NewFakeClipboard())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && m.Backend() != tt.want {
+				t.Errorf("Backend() = %q, want %q", m.Backend(), tt.want)
+			}
+		})
+	}
+
+	t.Run("auto without a display polls", func(t *testing.T) {
+		t.Setenv("WAYLAND_DISPLAY", "")
+		t.Setenv("DISPLAY", "")
+		m, err := New(config.MonitorConfig{Backend: "auto", PollInterval: time.Second}, NewFakeClipboard())
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if m.Backend() != "poll" {
+			t.Errorf("Backend() = %q, want poll", m.Backend())
+		}
+	})
+}
@@ -1,41 +1,289 @@
 package monitor
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
 	"time"
 
-	"github.com/atotto/clipboard"
+	"github.com/geodask/clipboard-manager/internal/config"
 	"github.com/geodask/clipboard-manager/internal/domain"
 )
 
+// Event reports a new clipboard entry, or an error from the monitor. Errors
+// do not stop the monitor.
+type Event struct {
+	Entry *domain.ClipboardEntry
+	Err   error
+}
+
+// Monitor watches the clipboard. Events is closed once ctx is done. Write
+// puts content on the clipboard without it being reported as an event.
 type Monitor interface {
-	Check() (entry *domain.ClipboardEntry, changed bool, err error)
+	Events(ctx context.Context) <-chan Event
+	Write(content string) error
+	Backend() string
+	Status() domain.MonitorStatus
 }
 
-type PollingMonitor struct {
-	lastContent string
+// New returns a monitor for the selections in cfg, using the backend in
+// cfg.Backend for each. "auto" prefers wl-paste on Wayland, then clipnotify
+// on X11, and polls otherwise; it always polls a tmux or command clipboard,
+// which the desktop cannot report changes to. clipboard is used for the
+// clipboard selection and the others are opened with NewClipboard. Polling,
+// including the fallback when events fail, backs off as set in cfg.
+func New(cfg config.MonitorConfig, clipboard Clipboard) (*SelectionMonitor, error) {
+	backend := cfg.Backend
+	if backend == "" || backend == "auto" {
+		backend = detectBackend()
+		if cfg.Clipboard == "tmux" || cfg.Clipboard == "command" {
+			backend = "poll"
+		}
+	}
+	if backend != "wayland" && backend != "x11" && backend != "poll" {
+		return nil, fmt.Errorf("unknown monitor backend %q (want auto, wayland, x11 or poll)", cfg.Backend)
+	}
+
+	names := cfg.Selections
+	if len(names) == 0 {
+		names = []string{string(domain.SelectionClipboard)}
+	}
+
+	backoff := Backoff{
+		Min:      cfg.PollInterval,
+		Max:      cfg.MaxPollInterval,
+		ErrorMax: cfg.ErrorBackoffMax,
+	}
+
+	var order []domain.Selection
+	monitors := make(map[domain.Selection]Monitor)
+	for _, name := range names {
+		selection, ok := domain.ParseSelection(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown selection %q (want clipboard, primary or secondary)", name)
+		}
+		if _, dup := monitors[selection]; dup {
+			continue
+		}
+
+		selectionClipboard := clipboard
+		if selection != domain.SelectionClipboard {
+			var err error
+			if selectionClipboard, err = NewClipboard(cfg, selection); err != nil {
+				return nil, err
+			}
+		}
+
+		switch backend {
+		case "wayland":
+			if selection == domain.SelectionSecondary {
+				return nil, fmt.Errorf("the %s selection is not available on Wayland", selection)
+			}
+			monitors[selection] = NewEventMonitor(selectionClipboard, NewWaylandNotifier(selection), backoff)
+		case "x11":
+			monitors[selection] = NewEventMonitor(selectionClipboard, NewXFixesNotifier(selection), backoff)
+		default:
+			monitors[selection] = NewPollingMonitor(selectionClipboard, backoff)
+		}
+		order = append(order, selection)
+	}
+
+	debounced := []domain.Selection{domain.SelectionPrimary, domain.SelectionSecondary}
+	if cfg.DebounceClipboard {
+		debounced = append(debounced, domain.SelectionClipboard)
+	}
+	sm := NewSelectionMonitor(monitors, order, debounced, cfg.SelectionDebounce)
+	sm.newClipboard = func(selection domain.Selection) (Clipboard, error) {
+		return NewClipboard(cfg, selection)
+	}
+	return sm, nil
 }
 
-func NewPollingMonitor() *PollingMonitor {
-	return &PollingMonitor{}
+func detectBackend() string {
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if _, err := exec.LookPath("wl-paste"); err == nil {
+			return "wayland"
+		}
+	}
+	if os.Getenv("DISPLAY") != "" {
+		if _, err := exec.LookPath("clipnotify"); err == nil {
+			return "x11"
+		}
+	}
+	return "poll"
 }
 
-func (pm *PollingMonitor) Check() (*domain.ClipboardEntry, bool, error) {
-	content, err := clipboard.ReadAll()
+// tracker reads the clipboard and reports content that differs from what
+// was last seen or written. It also keeps the status of the monitor that
+// embeds it.
+type tracker struct {
+	clipboard Clipboard
+
+	mu          sync.Mutex
+	lastContent string
+	writing     map[string]int // content of writes in progress
+	status      domain.MonitorStatus
+}
+
+// check holds the lock across the read so that a concurrent write cannot
+// make the content it replaced look new.
+func (t *tracker) check() (*domain.ClipboardEntry, bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	content, err := t.clipboard.Read()
 
 	if err != nil {
+		t.status.ErrorStreak++
+		t.status.LastError = err.Error()
+		t.status.LastErrorAt = time.Now()
 		return nil, false, err
 	}
+	t.status.ErrorStreak = 0
 
-	changed := content != pm.lastContent && content != ""
+	if t.writing[content] > 0 {
+		// Our own write landed before the command returned.
+		t.lastContent = content
+	}
+	changed := content != t.lastContent && content != ""
 
 	if changed {
-		pm.lastContent = content
+		t.lastContent = content
 		entry := &domain.ClipboardEntry{
 			Content:   content,
 			Timestamp: time.Now(),
 		}
+		t.status.LastChangeAt = entry.Timestamp
 		return entry, true, nil
 	}
 
 	return nil, false, nil
 }
+
+// Write puts content on the clipboard. The monitor does not report it as a
+// new entry. The lock is not held across the write, which may run an
+// external command, so checks carry on meanwhile.
+func (t *tracker) Write(content string) error {
+	t.mu.Lock()
+	if t.writing == nil {
+		t.writing = make(map[string]int)
+	}
+	t.writing[content]++
+	t.mu.Unlock()
+
+	err := t.clipboard.Write(content)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.writing[content]--; t.writing[content] == 0 {
+		delete(t.writing, content)
+	}
+	if err != nil {
+		return err
+	}
+	t.lastContent = content
+	return nil
+}
+
+func (t *tracker) setMode(mode string, interval time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.status.Mode = mode
+	t.status.Interval = interval
+}
+
+func (t *tracker) errorStreak() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.status.ErrorStreak
+}
+
+func (t *tracker) currentStatus() domain.MonitorStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.status
+}
+
+// emit checks the clipboard and sends the result, if any. It returns
+// whether the content changed, and false for ok once ctx is done.
+func (t *tracker) emit(ctx context.Context, events chan<- Event) (changed, ok bool) {
+	entry, changed, err := t.check()
+	if err == nil && !changed {
+		return false, ctx.Err() == nil
+	}
+
+	select {
+	case events <- Event{Entry: entry, Err: err}:
+		return changed, true
+	case <-ctx.Done():
+		return changed, false
+	}
+}
+
+// poll checks the clipboard until ctx is done, waiting between checks as
+// set by backoff.
+func (t *tracker) poll(ctx context.Context, backoff Backoff, events chan<- Event) {
+	s := backoff.scheduler()
+	t.setMode("poll", s.Min)
+
+	timer := time.NewTimer(s.Min)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			changed, ok := t.emit(ctx, events)
+			if !ok {
+				return
+			}
+			next := s.next(changed, t.errorStreak())
+			t.setMode("poll", next)
+			timer.Reset(next)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// PollingMonitor reads the whole clipboard on a schedule. It works
+// everywhere but costs a read per poll and misses copies replaced between
+// two polls.
+type PollingMonitor struct {
+	tracker
+	backoff Backoff
+}
+
+func NewPollingMonitor(clipboard Clipboard, backoff Backoff) *PollingMonitor {
+	pm := &PollingMonitor{
+		tracker: tracker{clipboard: clipboard},
+		backoff: backoff,
+	}
+	pm.status = domain.MonitorStatus{Backend: "poll", Mode: "poll", Interval: backoff.Min}
+	return pm
+}
+
+func (pm *PollingMonitor) Backend() string {
+	return "poll"
+}
+
+func (pm *PollingMonitor) Status() domain.MonitorStatus {
+	return pm.currentStatus()
+}
+
+func (pm *PollingMonitor) Check() (*domain.ClipboardEntry, bool, error) {
+	return pm.check()
+}
+
+func (pm *PollingMonitor) Events(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		pm.poll(ctx, pm.backoff, events)
+	}()
+	return events
+}
@@ -0,0 +1,75 @@
+package monitor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPollingMonitor(t *testing.T) {
+	clipboard := NewFakeClipboard()
+	pm := NewPollingMonitor(clipboard, Backoff{Min: time.Hour})
+
+	steps := []struct {
+		name        string
+		copy        string // set by another application before the check
+		write       string // written through the monitor before the check
+		wantChanged bool
+		wantContent string
+	}{
+		{name: "empty clipboard"},
+		{name: "new copy", copy: "first", wantChanged: true, wantContent: "first"},
+		{name: "unchanged"},
+		{name: "own write is not captured", write: "from history"},
+		{name: "copy after write", copy: "second", wantChanged: true, wantContent: "second"},
+		{name: "copying the written content again is captured", copy: "from history", wantChanged: true, wantContent: "from history"},
+	}
+
+	for _, step := range steps {
+		if step.copy != "" {
+			clipboard.Set(step.copy)
+		}
+		if step.write != "" {
+			if err := pm.Write(step.write); err != nil {
+				t.Fatalf("%s: Write() error = %v", step.name, err)
+			}
+		}
+
+		entry, changed, err := pm.Check()
+		if err != nil {
+			t.Fatalf("%s: Check() error = %v", step.name, err)
+		}
+		if changed != step.wantChanged {
+			t.Fatalf("%s: Check() changed = %v, want %v", step.name, changed, step.wantChanged)
+		}
+		if changed && entry.Content != step.wantContent {
+			t.Errorf("%s: Check() content = %q, want %q", step.name, entry.Content, step.wantContent)
+		}
+	}
+
+	if writes := clipboard.Writes(); len(writes) != 1 || writes[0] != "from history" {
+		t.Errorf("Writes() = %q, want [\"from history\"]", writes)
+	}
+}
+
+func TestPollingMonitor_Errors(t *testing.T) {
+	clipboard := NewFakeClipboard()
+	pm := NewPollingMonitor(clipboard, Backoff{Min: time.Hour})
+
+	clipboard.FailReads(errors.New("no display"))
+	if _, _, err := pm.Check(); err == nil {
+		t.Error("Check() expected the read error")
+	}
+
+	clipboard.FailReads(nil)
+	clipboard.FailWrites(errors.New("no display"))
+	if err := pm.Write("lost"); err == nil {
+		t.Error("Write() expected the write error")
+	}
+
+	clipboard.FailWrites(nil)
+	clipboard.Set("lost")
+	if _, changed, _ := pm.Check(); !changed {
+		t.Error("a failed write must not suppress capturing the same content later")
+	}
+}
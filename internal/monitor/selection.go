@@ -0,0 +1,291 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// CommandClipboard reads and writes a clipboard through external commands.
+// The write command gets the content on stdin.
+type CommandClipboard struct {
+	read  []string
+	write []string
+}
+
+func NewCommandClipboard(read, write []string) *CommandClipboard {
+	return &CommandClipboard{read: read, write: write}
+}
+
+func (c *CommandClipboard) Read() (string, error) {
+	out, err := exec.Command(c.read[0], c.read[1:]...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%s: %w: %s", strings.Join(c.read, " "), err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("%s: %w", strings.Join(c.read, " "), err)
+	}
+	return string(out), nil
+}
+
+// Write returns once the write command exits. Tools such as xclip fork a
+// child that keeps serving the selection, so stderr goes to a file rather
+// than a pipe: Run would otherwise wait for that child too.
+func (c *CommandClipboard) Write(content string) error {
+	stderr, err := os.CreateTemp("", "clipd-write-*")
+	if err != nil {
+		return fmt.Errorf("%s: %w", strings.Join(c.write, " "), err)
+	}
+	defer os.Remove(stderr.Name())
+	defer stderr.Close()
+
+	cmd := exec.Command(c.write[0], c.write[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		msg, _ := os.ReadFile(stderr.Name())
+		return fmt.Errorf("%s: %w: %s", strings.Join(c.write, " "), err, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// NewSelectionClipboard picks wl-clipboard on Wayland, then xclip, then xsel.
+// Wayland has no secondary selection.
+func NewSelectionClipboard(selection domain.Selection) (*CommandClipboard, error) {
+	for _, tool := range selectionTools() {
+		if read, write, ok := selectionCommands(tool, selection); ok {
+			return NewCommandClipboard(read, write), nil
+		}
+		if tool == "wl-clipboard" {
+			return nil, fmt.Errorf("the %s selection is not available on Wayland", selection)
+		}
+	}
+	return nil, fmt.Errorf("reading the %s selection needs wl-clipboard, xclip or xsel", selection)
+}
+
+func selectionTools() []string {
+	var tools []string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if _, err := exec.LookPath("wl-paste"); err == nil {
+			tools = append(tools, "wl-clipboard")
+		}
+	}
+	for _, tool := range []string{"xclip", "xsel"} {
+		if _, err := exec.LookPath(tool); err == nil {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// selectionCommands returns the commands a clipboard tool uses for a
+// selection. tmux has a single paste buffer, used as the clipboard.
+func selectionCommands(tool string, selection domain.Selection) (read, write []string, ok bool) {
+	switch tool {
+	case "wl-clipboard":
+		switch selection {
+		case domain.SelectionClipboard:
+			return []string{"wl-paste", "--no-newline"}, []string{"wl-copy"}, true
+		case domain.SelectionPrimary:
+			return []string{"wl-paste", "--primary", "--no-newline"}, []string{"wl-copy", "--primary"}, true
+		}
+	case "xclip":
+		return []string{"xclip", "-out", "-selection", string(selection)}, []string{"xclip", "-in", "-selection", string(selection)}, true
+	case "xsel":
+		return []string{"xsel", "--output", "--" + string(selection)}, []string{"xsel", "--input", "--" + string(selection)}, true
+	case "tmux":
+		if selection == domain.SelectionClipboard {
+			return []string{"tmux", "save-buffer", "-"}, []string{"tmux", "load-buffer", "-"}, true
+		}
+	}
+	return nil, nil, false
+}
+
+// SelectionMonitor merges one monitor per selection. It tags every entry
+// with its selection, debounces the selections in debounced, and drops an
+// entry that repeats the last one stored from another selection, as when
+// text is selected and then copied.
+type SelectionMonitor struct {
+	monitors  map[domain.Selection]Monitor
+	order     []domain.Selection
+	debounced map[domain.Selection]bool
+	delay     time.Duration
+
+	// newClipboard opens a selection that is written but not monitored.
+	newClipboard func(domain.Selection) (Clipboard, error)
+
+	mu   sync.Mutex
+	last string
+}
+
+// NewSelectionMonitor takes the monitors in the order given by order.
+// Selections in debounced are reported only once they have been stable for
+// delay, so a drag selection is stored once rather than as every prefix.
+func NewSelectionMonitor(monitors map[domain.Selection]Monitor, order []domain.Selection, debounced []domain.Selection, delay time.Duration) *SelectionMonitor {
+	sm := &SelectionMonitor{
+		monitors:  monitors,
+		order:     order,
+		debounced: make(map[domain.Selection]bool),
+		delay:     delay,
+		newClipboard: func(selection domain.Selection) (Clipboard, error) {
+			return NewSelectionClipboard(selection)
+		},
+	}
+	for _, selection := range debounced {
+		sm.debounced[selection] = true
+	}
+	return sm
+}
+
+func (sm *SelectionMonitor) Backend() string {
+	return sm.monitors[sm.order[0]].Backend()
+}
+
+// Status returns the status of each selection's monitor, in order.
+func (sm *SelectionMonitor) Status() []domain.MonitorStatus {
+	statuses := make([]domain.MonitorStatus, 0, len(sm.order))
+	for _, selection := range sm.order {
+		status := sm.monitors[selection].Status()
+		status.Selection = selection
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// Selections returns the monitored selections.
+func (sm *SelectionMonitor) Selections() []domain.Selection {
+	return append([]domain.Selection(nil), sm.order...)
+}
+
+func (sm *SelectionMonitor) Events(ctx context.Context) <-chan Event {
+	out := make(chan Event)
+
+	var wg sync.WaitGroup
+	for _, selection := range sm.order {
+		events := sm.monitors[selection].Events(ctx)
+		if sm.debounced[selection] && sm.delay > 0 {
+			events = debounce(ctx, events, sm.delay)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range events {
+				if event.Entry != nil {
+					event.Entry.Metadata.Selection = selection
+					if !sm.remember(event.Entry.Content) {
+						continue
+					}
+				}
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// remember records content as the last entry and reports whether it
+// differs from the previous one.
+func (sm *SelectionMonitor) remember(content string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if content == sm.last {
+		return false
+	}
+	sm.last = content
+	return true
+}
+
+// Write puts content on the clipboard selection.
+func (sm *SelectionMonitor) Write(content string) error {
+	return sm.WriteSelection(domain.SelectionClipboard, content)
+}
+
+// WriteSelection puts content on a selection. A monitored selection does
+// not report the write as a new entry.
+func (sm *SelectionMonitor) WriteSelection(selection domain.Selection, content string) error {
+	if m, ok := sm.monitors[selection]; ok {
+		if err := m.Write(content); err != nil {
+			return err
+		}
+		sm.remember(content)
+		return nil
+	}
+
+	clipboard, err := sm.newClipboard(selection)
+	if err != nil {
+		return err
+	}
+	return clipboard.Write(content)
+}
+
+// debounce passes on an entry only when no newer one arrives within delay.
+// Errors are passed on at once.
+func debounce(ctx context.Context, in <-chan Event, delay time.Duration) <-chan Event {
+	out := make(chan Event)
+
+	go func() {
+		defer close(out)
+
+		var pending *Event
+		timer := time.NewTimer(delay)
+		timer.Stop()
+		defer timer.Stop()
+
+		send := func(event Event) bool {
+			select {
+			case out <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case event, ok := <-in:
+				if !ok {
+					return
+				}
+				if event.Err != nil {
+					if !send(event) {
+						return
+					}
+					continue
+				}
+				pending = &event
+				timer.Reset(delay)
+			case <-timer.C:
+				if pending != nil {
+					if !send(*pending) {
+						return
+					}
+					pending = nil
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,168 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+func TestSelectionMonitor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clipboard, primary := NewFakeClipboard(), NewFakeClipboard()
+	clipboardNotifier, primaryNotifier := NewFakeNotifier(), NewFakeNotifier()
+	sm := NewSelectionMonitor(
+		map[domain.Selection]Monitor{
+			domain.SelectionClipboard: NewEventMonitor(clipboard, clipboardNotifier, Backoff{Min: time.Hour}),
+			domain.SelectionPrimary:   NewEventMonitor(primary, primaryNotifier, Backoff{Min: time.Hour}),
+		},
+		[]domain.Selection{domain.SelectionClipboard, domain.SelectionPrimary},
+		[]domain.Selection{domain.SelectionPrimary},
+		200*time.Millisecond,
+	)
+
+	events := sm.Events(ctx)
+
+	// A drag selection grows quickly; only the final text is reported.
+	for _, partial := range []string{"h", "he", "hel", "hello"} {
+		primary.Set(partial)
+		primaryNotifier.Notify()
+	}
+	event := receive(t, events)
+	if event.Entry == nil || event.Entry.Content != "hello" || event.Entry.Metadata.Selection != domain.SelectionPrimary {
+		t.Fatalf("expected the final primary selection, got %+v", event.Entry)
+	}
+
+	// Copying the selected text puts the same content on the clipboard,
+	// which is not stored twice.
+	clipboard.Set("hello")
+	clipboardNotifier.Notify()
+	clipboard.Set("copied")
+	clipboardNotifier.Notify()
+	event = receive(t, events)
+	if event.Entry == nil || event.Entry.Content != "copied" || event.Entry.Metadata.Selection != domain.SelectionClipboard {
+		t.Fatalf("expected the clipboard copy, got %+v", event.Entry)
+	}
+
+	// Writing to a monitored selection is not captured.
+	if err := sm.WriteSelection(domain.SelectionPrimary, "from history"); err != nil {
+		t.Fatalf("WriteSelection() error = %v", err)
+	}
+	primaryNotifier.Notify()
+	clipboard.Set("next")
+	clipboardNotifier.Notify()
+	event = receive(t, events)
+	if event.Entry == nil || event.Entry.Content != "next" {
+		t.Fatalf("expected the next copy, got %+v", event.Entry)
+	}
+	if writes := primary.Writes(); !reflect.DeepEqual(writes, []string{"from history"}) {
+		t.Errorf("primary Writes() = %q", writes)
+	}
+	if err := sm.Write("to clipboard"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if writes := clipboard.Writes(); !reflect.DeepEqual(writes, []string{"to clipboard"}) {
+		t.Errorf("clipboard Writes() = %q", writes)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	in := make(chan Event)
+	out := debounce(ctx, in, 200*time.Millisecond)
+
+	entry := func(content string) Event {
+		return Event{Entry: &domain.ClipboardEntry{Content: content}}
+	}
+
+	in <- entry("a")
+	in <- entry("ab")
+	in <- Event{Err: errors.New("read failed")}
+	if event := receive(t, out); event.Err == nil {
+		t.Fatalf("expected the error to pass straight through, got %+v", event)
+	}
+	in <- entry("abc")
+	if event := receive(t, out); event.Entry == nil || event.Entry.Content != "abc" {
+		t.Fatalf("expected only the last entry, got %+v", event)
+	}
+
+	in <- entry("later")
+	if event := receive(t, out); event.Entry == nil || event.Entry.Content != "later" {
+		t.Fatalf("expected the next entry once stable, got %+v", event)
+	}
+
+	close(in)
+	if _, ok := <-out; ok {
+		t.Error("expected the output to close with the input")
+	}
+}
+
+func TestSelectionCommands(t *testing.T) {
+	tests := []struct {
+		tool      string
+		selection domain.Selection
+		wantRead  []string
+		wantWrite []string
+		wantOK    bool
+	}{
+		{
+			tool:      "wl-clipboard",
+			selection: domain.SelectionPrimary,
+			wantRead:  []string{"wl-paste", "--primary", "--no-newline"},
+			wantWrite: []string{"wl-copy", "--primary"},
+			wantOK:    true,
+		},
+		{
+			tool:      "wl-clipboard",
+			selection: domain.SelectionSecondary,
+		},
+		{
+			tool:      "xclip",
+			selection: domain.SelectionSecondary,
+			wantRead:  []string{"xclip", "-out", "-selection", "secondary"},
+			wantWrite: []string{"xclip", "-in", "-selection", "secondary"},
+			wantOK:    true,
+		},
+		{
+			tool:      "tmux",
+			selection: domain.SelectionClipboard,
+			wantRead:  []string{"tmux", "save-buffer", "-"},
+			wantWrite: []string{"tmux", "load-buffer", "-"},
+			wantOK:    true,
+		},
+		{
+			tool:      "tmux",
+			selection: domain.SelectionPrimary,
+		},
+		{
+			tool:      "xsel",
+			selection: domain.SelectionPrimary,
+			wantRead:  []string{"xsel", "--output", "--primary"},
+			wantWrite: []string{"xsel", "--input", "--primary"},
+			wantOK:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool+"/"+string(tt.selection), func(t *testing.T) {
+			read, write, ok := selectionCommands(tt.tool, tt.selection)
+			if ok != tt.wantOK {
+				t.Fatalf("selectionCommands() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(read, tt.wantRead) || !reflect.DeepEqual(write, tt.wantWrite) {
+				t.Errorf("selectionCommands() = %q, %q, want %q, %q", read, write, tt.wantRead, tt.wantWrite)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// maxFeedBytes limits one entry read from a FIFO or a watched file.
+const maxFeedBytes = 1 << 20
+
+// Source is a capture source besides the clipboard monitor.
+type Source interface {
+	Name() string
+	Events(ctx context.Context) <-chan Event
+}
+
+// NewSources returns the extra sources enabled in cfg. The tmux source
+// polls with the same backoff as the clipboard monitor in monitorCfg.
+func NewSources(cfg config.SourcesConfig, monitorCfg config.MonitorConfig) ([]Source, error) {
+	var sources []Source
+
+	if cfg.FeedFIFO != "" {
+		fifo, err := NewFIFOSource(cfg.FeedFIFO)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, fifo)
+	}
+
+	for _, path := range cfg.WatchFiles {
+		sources = append(sources, NewFileSource(path, cfg.PollInterval))
+	}
+
+	if cfg.TmuxBuffers {
+		sources = append(sources, NewTmuxSource(Backoff{
+			Min:      monitorCfg.PollInterval,
+			Max:      monitorCfg.MaxPollInterval,
+			ErrorMax: monitorCfg.ErrorBackoffMax,
+		}))
+	}
+
+	return sources, nil
+}
+
+// FIFOSource reads entries from a named pipe. Everything written between a
+// writer opening and closing the pipe is one entry, so `cmd | clipd-feed`
+// stores the whole output of cmd.
+type FIFOSource struct {
+	path string
+}
+
+// NewFIFOSource creates the pipe at path unless it already exists.
+func NewFIFOSource(path string) (*FIFOSource, error) {
+	info, err := os.Stat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if err := syscall.Mkfifo(path, 0o600); err != nil {
+			return nil, fmt.Errorf("creating feed pipe %s: %w", path, err)
+		}
+	case err != nil:
+		return nil, err
+	case info.Mode()&fs.ModeNamedPipe == 0:
+		return nil, fmt.Errorf("%s exists and is not a named pipe", path)
+	}
+	return &FIFOSource{path: path}, nil
+}
+
+func (s *FIFOSource) Name() string {
+	return "fifo"
+}
+
+func (s *FIFOSource) Events(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+
+	// Opening the pipe blocks until a writer arrives, so opening it for
+	// writing ourselves is what lets the reader see ctx is done.
+	stop := context.AfterFunc(ctx, func() {
+		if w, err := os.OpenFile(s.path, os.O_WRONLY|syscall.O_NONBLOCK, 0); err == nil {
+			w.Close()
+		}
+	})
+
+	go func() {
+		defer close(events)
+		defer stop()
+
+		for ctx.Err() == nil {
+			content, err := s.read(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err == nil && content == "" {
+				continue
+			}
+
+			event := Event{Err: err}
+			if err == nil {
+				event.Entry = &domain.ClipboardEntry{Content: content, Timestamp: time.Now()}
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				// Do not spin if the pipe has gone away.
+				select {
+				case <-time.After(time.Second):
+				case <-ctx.Done():
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+func (s *FIFOSource) read(ctx context.Context) (string, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return "", fmt.Errorf("opening feed pipe: %w", err)
+	}
+	defer f.Close()
+
+	// A writer that never closes the pipe must not keep the daemon from
+	// shutting down.
+	stop := context.AfterFunc(ctx, func() {
+		f.SetReadDeadline(time.Now())
+	})
+	defer stop()
+
+	content, err := io.ReadAll(io.LimitReader(f, maxFeedBytes))
+	if err != nil {
+		return "", fmt.Errorf("reading feed pipe: %w", err)
+	}
+	return string(content), nil
+}
+
+// FileSource follows a file like tail -f: every line appended after it
+// starts becomes an entry. A file that shrinks is read again from the
+// start, as after log rotation.
+type FileSource struct {
+	path     string
+	interval time.Duration
+}
+
+func NewFileSource(path string, interval time.Duration) *FileSource {
+	return &FileSource{path: path, interval: interval}
+}
+
+func (s *FileSource) Name() string {
+	return "file:" + s.path
+}
+
+func (s *FileSource) Events(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+
+	// Only lines appended from now on are recorded.
+	var offset int64
+	if info, err := os.Stat(s.path); err == nil {
+		offset = info.Size()
+	}
+
+	go func() {
+		defer close(events)
+
+		var partial []byte
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+
+			lines, next, err := s.readLines(offset, &partial)
+			offset = next
+			if err != nil {
+				lines = nil
+				select {
+				case events <- Event{Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			for _, line := range lines {
+				entry := &domain.ClipboardEntry{Content: line, Timestamp: time.Now()}
+				select {
+				case events <- Event{Entry: entry}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+// readLines returns the complete, non-blank lines written after offset and
+// the offset to continue from. A trailing line without a newline is kept in
+// partial until it is finished.
+func (s *FileSource) readLines(offset int64, partial *[]byte) ([]string, int64, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Not created yet, or being rotated.
+		*partial = nil
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, offset, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, offset, err
+	}
+	if info.Size() < offset {
+		offset = 0
+		*partial = nil
+	}
+	if info.Size() == offset {
+		return nil, offset, nil
+	}
+
+	data := make([]byte, min(info.Size()-offset, maxFeedBytes))
+	n, err := f.ReadAt(data, offset)
+	if err != nil && err != io.EOF {
+		return nil, offset, err
+	}
+	offset += int64(n)
+
+	data = append(*partial, data[:n]...)
+	end := bytes.LastIndexByte(data, '\n')
+	*partial = append([]byte(nil), data[end+1:]...)
+	if end < 0 {
+		return nil, offset, nil
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(data[:end]), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, offset, nil
+}
+
+// TmuxSource records the newest tmux paste buffer, polling it like the
+// clipboard.
+type TmuxSource struct {
+	*PollingMonitor
+}
+
+func NewTmuxSource(backoff Backoff) *TmuxSource {
+	read, write, _ := selectionCommands("tmux", domain.SelectionClipboard)
+	return &TmuxSource{NewPollingMonitor(tmuxBuffers{NewCommandClipboard(read, write)}, backoff)}
+}
+
+func (s *TmuxSource) Name() string {
+	return "tmux"
+}
+
+// tmuxBuffers treats a tmux server without buffers as an empty clipboard
+// rather than a failing one.
+type tmuxBuffers struct {
+	*CommandClipboard
+}
+
+func (t tmuxBuffers) Read() (string, error) {
+	content, err := t.CommandClipboard.Read()
+	if err != nil && strings.Contains(err.Error(), "no buffers") {
+		return "", nil
+	}
+	return content, err
+}
@@ -0,0 +1,121 @@
+package monitor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "notes.log")
+	if err := os.WriteFile(path, []byte("already there\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	appendTo := func(s string) {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	source := NewFileSource(path, 10*time.Millisecond)
+	if source.Name() != "file:"+path {
+		t.Errorf("Name() = %q, want the path", source.Name())
+	}
+	events := source.Events(ctx)
+
+	steps := []struct {
+		name  string
+		write func()
+		want  []string
+	}{
+		{
+			name:  "appended lines, skipping blank ones",
+			write: func() { appendTo("one\n\ntwo\npar") },
+			want:  []string{"one", "two"},
+		},
+		{
+			name:  "a partial line once finished",
+			write: func() { appendTo("tial\n") },
+			want:  []string{"partial"},
+		},
+		{
+			name: "a rotated file from the start",
+			write: func() {
+				if err := os.WriteFile(path, []byte("new\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: []string{"new"},
+		},
+	}
+
+	for _, step := range steps {
+		step.write()
+		for _, want := range step.want {
+			if event := receive(t, events); event.Entry == nil || event.Entry.Content != want {
+				t.Fatalf("%s: got %+v, want %q", step.name, event, want)
+			}
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestFIFOSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "clipd.feed")
+	source, err := NewFIFOSource(path)
+	if err != nil {
+		t.Fatalf("NewFIFOSource() error = %v", err)
+	}
+	events := source.Events(ctx)
+
+	for _, content := range []string{"first feed\n", "second\nfeed"} {
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.WriteString(content)
+		f.Close()
+
+		if event := receive(t, events); event.Entry == nil || event.Entry.Content != content {
+			t.Fatalf("got %+v, want %q as one entry", event, content)
+		}
+	}
+
+	// Shutting down must not wait for another writer.
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("expected no more events after cancel")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("events not closed after cancel")
+	}
+
+	// The pipe is reused on the next start.
+	if _, err := NewFIFOSource(path); err != nil {
+		t.Errorf("NewFIFOSource() on an existing pipe error = %v", err)
+	}
+
+	regular := filepath.Join(t.TempDir(), "regular")
+	os.WriteFile(regular, nil, 0o644)
+	if _, err := NewFIFOSource(regular); err == nil {
+		t.Error("NewFIFOSource() expected an error for a regular file")
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// DryRun is what ProcessNewEntry would do with an entry. Analysis is nil
+// when an ignore rule drops the entry first; Content is the entry as it
+// would be stored, after redaction or URL cleaning.
+type DryRun struct {
+	IgnoredBy   string
+	Analysis    *domain.Analysis
+	Content     string
+	Redactions  []domain.Redaction
+	OriginalURL string
+}
+
+// DryRun runs the ignore rules and the full analyzer on entry without
+// storing it, vaulting it or counting it in the stats.
+func (s *ClipboardService) DryRun(ctx context.Context, entry *domain.ClipboardEntry) (*DryRun, error) {
+	if entry == nil {
+		return nil, ErrNilEntry
+	}
+
+	if entry.Content == "" {
+		return nil, ErrEmptyContent
+	}
+
+	if s.ignore != nil {
+		if rule := s.ignore.Match(entry); rule != "" {
+			return &DryRun{IgnoredBy: rule, Content: entry.Content}, nil
+		}
+	}
+
+	analysis := s.analyzer.Analyze(ctx, entry)
+
+	result := &DryRun{Analysis: analysis, Content: entry.Content}
+	if !analysis.IsSensitive {
+		result.Content, result.Redactions, result.OriginalURL = storedContent(entry.Content, analysis)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+func TestDryRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		analysis       *domain.Analysis
+		wantErr        error
+		wantIgnoredBy  string
+		wantContent    string
+		wantRedactions int
+		wantOriginal   string
+	}{
+		{
+			name:        "stored unchanged",
+			content:     "hello",
+			wantContent: "hello",
+		},
+		{
+			name:    "redacted",
+			content: "mail jane@example.com",
+			analysis: &domain.Analysis{
+				Matches: []domain.Match{{Rule: "email", Action: domain.ActionRedact, Start: 5, End: 21}},
+			},
+			wantContent:    "mail «redacted:email»",
+			wantRedactions: 1,
+		},
+		{
+			name:        "blocked keeps the original",
+			content:     "password=hunter2",
+			analysis:    &domain.Analysis{IsSensitive: true, Reason: "password"},
+			wantContent: "password=hunter2",
+		},
+		{
+			name:         "cleaned url",
+			content:      "https://example.com/?utm_source=x",
+			analysis:     &domain.Analysis{Type: domain.ContentTypeURL, CleanURL: "https://example.com/"},
+			wantContent:  "https://example.com/",
+			wantOriginal: "https://example.com/?utm_source=x",
+		},
+		{
+			name:        "url already clean",
+			content:     "https://example.com/\n",
+			analysis:    &domain.Analysis{Type: domain.ContentTypeURL, CleanURL: "https://example.com/"},
+			wantContent: "https://example.com/\n",
+		},
+		{
+			name:    "redacted url is not cleaned",
+			content: "https://example.com/?email=jane@example.com",
+			analysis: &domain.Analysis{
+				Type:     domain.ContentTypeURL,
+				CleanURL: "https://example.com/?email=jane@example.com",
+				Matches:  []domain.Match{{Rule: "email", Action: domain.ActionRedact, Start: 27, End: 43}},
+			},
+			wantContent:    "https://example.com/?email=«redacted:email»",
+			wantRedactions: 1,
+		},
+		{
+			name:          "ignored",
+			content:       "123456",
+			wantIgnoredBy: "pattern:^\\d{6}$",
+			wantContent:   "123456",
+		},
+		{
+			name:    "empty content",
+			wantErr: ErrEmptyContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := &MockStorage{}
+			mockVault := &MockVault{}
+			service := NewClipboardService(mockStorage, &MockAnalyzer{Result: tt.analysis})
+			service.EnableVault(mockVault)
+			service.EnableIgnoreRules(&MockIgnoreRules{Rules: map[string]string{"123456": "pattern:^\\d{6}$"}})
+
+			got, err := service.DryRun(context.Background(), &domain.ClipboardEntry{Content: tt.content})
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if got.IgnoredBy != tt.wantIgnoredBy {
+				t.Errorf("expected IgnoredBy %q, got %q", tt.wantIgnoredBy, got.IgnoredBy)
+			}
+			if (got.Analysis == nil) != (tt.wantIgnoredBy != "") {
+				t.Errorf("expected analysis only when not ignored, got %+v", got.Analysis)
+			}
+			if got.Content != tt.wantContent {
+				t.Errorf("expected content %q, got %q", tt.wantContent, got.Content)
+			}
+			if got.OriginalURL != tt.wantOriginal {
+				t.Errorf("expected original URL %q, got %q", tt.wantOriginal, got.OriginalURL)
+			}
+			if len(got.Redactions) != tt.wantRedactions {
+				t.Errorf("expected %d redactions, got %d", tt.wantRedactions, len(got.Redactions))
+			}
+
+			if mockStorage.StoreCalled || len(mockVault.PutCalls) > 0 {
+				t.Error("expected a dry run not to store or vault the entry")
+			}
+			if len(service.ignoredByRule) > 0 || len(service.sensitiveByReason) > 0 {
+				t.Error("expected a dry run not to count in the stats")
+			}
+		})
+	}
+}
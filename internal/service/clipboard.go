@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/geodask/clipboard-manager/internal/domain"
@@ -10,32 +12,91 @@ import (
 
 type Storage interface {
 	Store(ctx context.Context, entry *domain.ClipboardEntry) (*domain.ClipboardEntry, error)
-	GetRecent(ctx context.Context, n int) ([]*domain.ClipboardEntry, error)
+	GetRecent(ctx context.Context, filter domain.HistoryFilter, n int) ([]*domain.ClipboardEntry, error)
 	GetById(ctx context.Context, id string) (*domain.ClipboardEntry, error)
 	Delete(ctx context.Context, id string) error
 	Search(ctx context.Context, query string, limit int) ([]*domain.ClipboardEntry, error)
 	Count(ctx context.Context) (int, error)
 	Clear(ctx context.Context) error
 	DeleteOlderThan(ctx context.Context, cutoff time.Time) (int, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+	MarkUsed(ctx context.Context, id string, at time.Time) error
+	Stats(ctx context.Context, query domain.StatsQuery) (*domain.HistoryStats, error)
+	Maintain(ctx context.Context) (*domain.MaintenanceReport, error)
 }
 
 type Analyzer interface {
-	Analyze(entry *domain.ClipboardEntry) *domain.Analysis
+	Analyze(ctx context.Context, entry *domain.ClipboardEntry) *domain.Analysis
 }
 
+const (
+	defaultStatsBucket  = 24 * time.Hour
+	defaultStatsLargest = 5
+	maxStatsBuckets     = 1000
+)
+
 type ClipboardService struct {
 	storage  Storage
 	analyzer Analyzer
+
+	vault     Vault
+	ignore    IgnoreRules
+	clipboard ClipboardWriter
+	monitor   MonitorReporter
+
+	profiles      ProfileStore
+	scope         func(profile string) Storage
+	activeProfile string
+	profileNames  map[string]bool // cached, so captures need no lookup
+	profileMu     sync.Mutex      // serializes creating, deleting and activating profiles
+
+	mu                sync.Mutex
+	sensitiveByReason map[string]int
+	ignoredByRule     map[string]int
+	lastMaintenance   *domain.MaintenanceReport
+	pause             domain.PauseState
+	pauseStore        PauseStore
+	pauseMu           sync.Mutex // serializes saving and applying pause changes
+
+	maintenanceMu sync.Mutex
 }
 
 type Stats struct {
-	TotalEntries int
+	Profile           string
+	TotalEntries      int
+	TotalBytes        int64
+	AverageBytes      float64
+	CountsByType      map[domain.ContentType]int
+	Largest           []domain.EntrySize
+	Activity          []domain.ActivityBucket
+	Window            time.Duration
+	Bucket            time.Duration
+	SensitiveSkipped  int
+	SensitiveByReason map[string]int
+	IgnoredSkipped    int
+	IgnoredByRule     map[string]int
+	Oldest            time.Time
+	Newest            time.Time
+	DatabaseBytes     int64
+	Monitor           []domain.MonitorStatus // nil when no monitor is enabled
+	Pause             domain.PauseState
+}
+
+// StatsOptions controls the activity histogram and the largest-entries list.
+// Zero values fall back to daily buckets over the last week.
+type StatsOptions struct {
+	Window  time.Duration
+	Bucket  time.Duration
+	Largest int
 }
 
 func NewClipboardService(storage Storage, analyzer Analyzer) *ClipboardService {
 	return &ClipboardService{
-		storage:  storage,
-		analyzer: analyzer,
+		storage:           storage,
+		analyzer:          analyzer,
+		activeProfile:     domain.DefaultProfile,
+		sensitiveByReason: make(map[string]int),
+		ignoredByRule:     make(map[string]int),
 	}
 }
 
@@ -48,15 +109,43 @@ func (s *ClipboardService) ProcessNewEntry(ctx context.Context, entry *domain.Cl
 		return nil, ErrEmptyContent
 	}
 
-	analysis := s.analyzer.Analyze(entry)
+	if s.ignore != nil {
+		if rule := s.ignore.Match(entry); rule != "" {
+			s.recordIgnored(rule)
+			return nil, &IgnoredContentError{Rule: rule}
+		}
+	}
+
+	analysis := s.analyzer.Analyze(ctx, entry)
 
 	if analysis.IsSensitive {
-		return nil, &SensitiveContentError{
-			Reason: analysis.Reason,
+		s.recordSensitiveSkip(analysis.Reason)
+		sensitiveErr := &SensitiveContentError{
+			Reason:     analysis.Reason,
+			Severity:   analysis.Severity,
+			Confidence: analysis.Confidence,
 		}
+		if s.vault != nil {
+			sensitiveErr.VaultId = s.vault.Put(entry, analysis).Id
+		}
+		return nil, sensitiveErr
+	}
+
+	entry.Type = analysis.Type
+	entry.Language = analysis.Language
+	entry.Domain = analysis.Domain
+	if analysis.TTL > 0 {
+		entry.ExpiresAt = time.Now().Add(analysis.TTL)
+	}
+	entry.Content, entry.Metadata.Redactions, entry.Metadata.OriginalURL = storedContent(entry.Content, analysis)
+
+	storage, profile, err := s.storageFor(ctx, entry.Profile)
+	if err != nil {
+		return nil, err
 	}
+	entry.Profile = profile
 
-	stored, err := s.storage.Store(ctx, entry)
+	stored, err := storage.Store(ctx, entry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to store entry: %w", err)
 	}
@@ -64,12 +153,19 @@ func (s *ClipboardService) ProcessNewEntry(ctx context.Context, entry *domain.Cl
 	return stored, nil
 }
 
-func (s *ClipboardService) GetHistory(ctx context.Context, limit int) ([]*domain.ClipboardEntry, error) {
+func (s *ClipboardService) GetHistory(ctx context.Context, profile string, filter domain.HistoryFilter, limit int) ([]*domain.ClipboardEntry, error) {
 	if limit <= 0 || limit > 100 {
 		return nil, ErrInvalidLimit
 	}
 
-	entries, err := s.storage.GetRecent(ctx, limit)
+	storage, _, err := s.storageFor(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Language = strings.ToLower(filter.Language)
+	filter.Domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(filter.Domain)), ".")
+	entries, err := storage.GetRecent(ctx, filter, limit)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get history: %w", err)
@@ -78,12 +174,17 @@ func (s *ClipboardService) GetHistory(ctx context.Context, limit int) ([]*domain
 	return entries, nil
 }
 
-func (s *ClipboardService) GetEntry(ctx context.Context, id string) (*domain.ClipboardEntry, error) {
+func (s *ClipboardService) GetEntry(ctx context.Context, profile, id string) (*domain.ClipboardEntry, error) {
 	if id == "" {
 		return nil, ErrInvalidId
 	}
 
-	entry, err := s.storage.GetById(ctx, id)
+	storage, _, err := s.storageFor(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := storage.GetById(ctx, id)
 	if err != nil {
 		return nil, ErrNotFound
 	}
@@ -91,12 +192,17 @@ func (s *ClipboardService) GetEntry(ctx context.Context, id string) (*domain.Cli
 	return entry, nil
 }
 
-func (s *ClipboardService) DeleteEntry(ctx context.Context, id string) error {
+func (s *ClipboardService) DeleteEntry(ctx context.Context, profile, id string) error {
 	if id == "" {
 		return ErrInvalidId
 	}
 
-	err := s.storage.Delete(ctx, id)
+	storage, _, err := s.storageFor(ctx, profile)
+	if err != nil {
+		return err
+	}
+
+	err = storage.Delete(ctx, id)
 	if err != nil {
 		return ErrNotFound
 	}
@@ -104,7 +210,7 @@ func (s *ClipboardService) DeleteEntry(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *ClipboardService) Search(ctx context.Context, query string, limit int) ([]*domain.ClipboardEntry, error) {
+func (s *ClipboardService) Search(ctx context.Context, profile, query string, limit int) ([]*domain.ClipboardEntry, error) {
 	if query == "" {
 		return nil, ErrEmptyQuery
 	}
@@ -113,7 +219,12 @@ func (s *ClipboardService) Search(ctx context.Context, query string, limit int)
 		limit = 100 // default
 	}
 
-	entries, err := s.storage.Search(ctx, query, limit)
+	storage, _, err := s.storageFor(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := storage.Search(ctx, query, limit)
 	if err != nil {
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
@@ -121,21 +232,125 @@ func (s *ClipboardService) Search(ctx context.Context, query string, limit int)
 	return entries, nil
 }
 
-func (s *ClipboardService) ClearHistory(ctx context.Context) error {
-	return s.storage.Clear(ctx)
+func (s *ClipboardService) ClearHistory(ctx context.Context, profile string) error {
+	storage, _, err := s.storageFor(ctx, profile)
+	if err != nil {
+		return err
+	}
+
+	return storage.Clear(ctx)
 }
 
-func (s *ClipboardService) GetStats(ctx context.Context) (*Stats, error) {
-	count, err := s.storage.Count(ctx)
+func (s *ClipboardService) GetStats(ctx context.Context, profile string, opts StatsOptions) (*Stats, error) {
+	if opts.Bucket == 0 {
+		opts.Bucket = defaultStatsBucket
+	}
+	if opts.Window == 0 {
+		opts.Window = 7 * opts.Bucket
+	}
+	if opts.Largest == 0 {
+		opts.Largest = defaultStatsLargest
+	}
+
+	if opts.Bucket < 0 || opts.Window < 0 || opts.Window/opts.Bucket > maxStatsBuckets {
+		return nil, ErrInvalidStatsWindow
+	}
+
+	storage, profile, err := s.storageFor(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	history, err := storage.Stats(ctx, domain.StatsQuery{
+		Since:   now.Add(-opts.Window),
+		Until:   now,
+		Bucket:  opts.Bucket,
+		Largest: opts.Largest,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stats: %w", err)
 	}
 
-	return &Stats{
-		TotalEntries: count,
-	}, nil
+	stats := &Stats{
+		Profile:       profile,
+		TotalEntries:  history.TotalEntries,
+		TotalBytes:    history.TotalBytes,
+		CountsByType:  history.CountsByType,
+		Largest:       history.Largest,
+		Activity:      history.Activity,
+		Window:        opts.Window,
+		Bucket:        opts.Bucket,
+		Oldest:        history.Oldest,
+		Newest:        history.Newest,
+		DatabaseBytes: history.DatabaseBytes,
+	}
+
+	if stats.TotalEntries > 0 {
+		stats.AverageBytes = float64(stats.TotalBytes) / float64(stats.TotalEntries)
+	}
+
+	s.mu.Lock()
+	stats.SensitiveByReason = make(map[string]int, len(s.sensitiveByReason))
+	for reason, count := range s.sensitiveByReason {
+		stats.SensitiveByReason[reason] = count
+		stats.SensitiveSkipped += count
+	}
+	stats.IgnoredByRule = make(map[string]int, len(s.ignoredByRule))
+	for rule, count := range s.ignoredByRule {
+		stats.IgnoredByRule[rule] = count
+		stats.IgnoredSkipped += count
+	}
+	stats.Pause = s.pause.At(now)
+	s.mu.Unlock()
+
+	stats.Monitor = s.MonitorStatus()
+
+	return stats, nil
+}
+
+func (s *ClipboardService) recordSensitiveSkip(reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sensitiveByReason[reason]++
 }
 
-func (s *ClipboardService) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int, error) {
-	return s.storage.DeleteOlderThan(ctx, cutoff)
+func (s *ClipboardService) DeleteOlderThan(ctx context.Context, profile string, cutoff time.Time) (int, error) {
+	storage, _, err := s.storageFor(ctx, profile)
+	if err != nil {
+		return 0, err
+	}
+
+	return storage.DeleteOlderThan(ctx, cutoff)
+}
+
+// RunMaintenance performs database housekeeping. Only one run may be in
+// progress at a time; concurrent callers get ErrMaintenanceInProgress.
+func (s *ClipboardService) RunMaintenance(ctx context.Context) (*domain.MaintenanceReport, error) {
+	if !s.maintenanceMu.TryLock() {
+		return nil, ErrMaintenanceInProgress
+	}
+	defer s.maintenanceMu.Unlock()
+
+	report, err := s.storage.Maintain(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance failed: %w", err)
+	}
+
+	s.mu.Lock()
+	s.lastMaintenance = report
+	s.mu.Unlock()
+
+	return report, nil
+}
+
+func (s *ClipboardService) LastMaintenance(ctx context.Context) (*domain.MaintenanceReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastMaintenance == nil {
+		return nil, ErrNoMaintenanceRun
+	}
+
+	return s.lastMaintenance, nil
 }
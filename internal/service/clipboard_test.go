@@ -24,11 +24,19 @@ type MockStorage struct {
 	ClearError            error
 	DeleteOlderThanResult int
 	DeleteOlderThanError  error
+	DeleteExpiredResult   int
+	DeleteExpiredError    error
+	MarkUsedError         error
+	StatsResult           *domain.HistoryStats
+	StatsError            error
+	MaintainResult        *domain.MaintenanceReport
+	MaintainError         error
 
 	StoreCalled           bool
 	StoreCalledWith       *domain.ClipboardEntry
 	GetRecentCalled       bool
 	GetRecentLimit        int
+	GetRecentFilter       domain.HistoryFilter
 	GetByIdCalled         bool
 	GetByIdId             string
 	DeleteCalled          bool
@@ -40,6 +48,12 @@ type MockStorage struct {
 	ClearCalled           bool
 	DeleteOlderThanCalled bool
 	DeleteOlderThanCutoff time.Time
+	DeleteExpiredCalled   bool
+	MarkUsedCalled        bool
+	MarkUsedId            string
+	StatsCalled           bool
+	StatsQuery            domain.StatsQuery
+	MaintainCalled        bool
 }
 
 func (m *MockStorage) Store(ctx context.Context, entry *domain.ClipboardEntry) (*domain.ClipboardEntry, error) {
@@ -48,9 +62,10 @@ func (m *MockStorage) Store(ctx context.Context, entry *domain.ClipboardEntry) (
 	return m.StoreResult, m.StoreError
 }
 
-func (m *MockStorage) GetRecent(ctx context.Context, n int) ([]*domain.ClipboardEntry, error) {
+func (m *MockStorage) GetRecent(ctx context.Context, filter domain.HistoryFilter, n int) ([]*domain.ClipboardEntry, error) {
 	m.GetRecentCalled = true
 	m.GetRecentLimit = n
+	m.GetRecentFilter = filter
 	return m.GetRecentResult, m.GetRecentError
 }
 
@@ -89,11 +104,33 @@ func (m *MockStorage) DeleteOlderThan(ctx context.Context, cutoff time.Time) (in
 	return m.DeleteOlderThanResult, m.DeleteOlderThanError
 }
 
+func (m *MockStorage) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	m.DeleteExpiredCalled = true
+	return m.DeleteExpiredResult, m.DeleteExpiredError
+}
+
+func (m *MockStorage) MarkUsed(ctx context.Context, id string, at time.Time) error {
+	m.MarkUsedCalled = true
+	m.MarkUsedId = id
+	return m.MarkUsedError
+}
+
+func (m *MockStorage) Stats(ctx context.Context, query domain.StatsQuery) (*domain.HistoryStats, error) {
+	m.StatsCalled = true
+	m.StatsQuery = query
+	return m.StatsResult, m.StatsError
+}
+
+func (m *MockStorage) Maintain(ctx context.Context) (*domain.MaintenanceReport, error) {
+	m.MaintainCalled = true
+	return m.MaintainResult, m.MaintainError
+}
+
 type MockAnalyzer struct {
 	Result *domain.Analysis
 }
 
-func (m *MockAnalyzer) Analyze(entry *domain.ClipboardEntry) *domain.Analysis {
+func (m *MockAnalyzer) Analyze(ctx context.Context, entry *domain.ClipboardEntry) *domain.Analysis {
 	if m.Result == nil {
 		return &domain.Analysis{
 			Type:        domain.ContentTypeText,
@@ -251,6 +288,8 @@ func TestGetHistory(t *testing.T) {
 	tests := []struct {
 		name                string
 		limit               int
+		filter              domain.HistoryFilter
+		wantFilter          domain.HistoryFilter
 		storageResult       []*domain.ClipboardEntry
 		storageError        error
 		wantErr             error
@@ -269,6 +308,28 @@ func TestGetHistory(t *testing.T) {
 			wantResult:          true,
 			wantGetRecentCalled: true, // Should call storage
 		},
+		{
+			name:   "LanguageFilter",
+			limit:  10,
+			filter: domain.HistoryFilter{Language: "Go"},
+			storageResult: []*domain.ClipboardEntry{
+				{Id: "1", Content: "package main", Type: domain.ContentTypeCode, Language: "go"},
+			},
+			wantFilter:          domain.HistoryFilter{Language: "go"},
+			wantResult:          true,
+			wantGetRecentCalled: true,
+		},
+		{
+			name:   "DomainFilter",
+			limit:  10,
+			filter: domain.HistoryFilter{Domain: " GitHub.com. "},
+			storageResult: []*domain.ClipboardEntry{
+				{Id: "1", Content: "https://github.com/", Type: domain.ContentTypeURL, Domain: "github.com"},
+			},
+			wantFilter:          domain.HistoryFilter{Domain: "github.com"},
+			wantResult:          true,
+			wantGetRecentCalled: true,
+		},
 		{
 			name:                "InvalidLimitZero",
 			limit:               0,
@@ -309,7 +370,7 @@ func TestGetHistory(t *testing.T) {
 
 			service := NewClipboardService(mockStorage, &MockAnalyzer{})
 
-			result, err := service.GetHistory(context.Background(), tt.limit)
+			result, err := service.GetHistory(context.Background(), "", tt.filter, tt.limit)
 
 			// Check error
 			if tt.wantErr != nil {
@@ -348,6 +409,11 @@ func TestGetHistory(t *testing.T) {
 				t.Errorf("expected GetRecentLimit=%d, got %d",
 					tt.limit, mockStorage.GetRecentLimit)
 			}
+
+			if mockStorage.GetRecentFilter != tt.wantFilter {
+				t.Errorf("expected GetRecentFilter=%+v, got %+v",
+					tt.wantFilter, mockStorage.GetRecentFilter)
+			}
 		})
 	}
 }
@@ -405,7 +471,7 @@ func TestGetEntry(t *testing.T) {
 
 			service := NewClipboardService(mockStorage, &MockAnalyzer{})
 
-			result, err := service.GetEntry(context.Background(), tt.id)
+			result, err := service.GetEntry(context.Background(), "", tt.id)
 
 			if tt.wantErr != nil {
 				if err == nil {
@@ -482,7 +548,7 @@ func TestDeleteEntry(t *testing.T) {
 
 			service := NewClipboardService(mockStorage, &MockAnalyzer{})
 
-			err := service.DeleteEntry(context.Background(), tt.id)
+			err := service.DeleteEntry(context.Background(), "", tt.id)
 
 			if tt.wantErr != nil {
 				if err == nil {
@@ -564,7 +630,7 @@ func TestSearch(t *testing.T) {
 
 			service := NewClipboardService(mockStorage, &MockAnalyzer{})
 
-			result, err := service.Search(context.Background(), tt.query, tt.limit)
+			result, err := service.Search(context.Background(), "", tt.query, tt.limit)
 
 			if tt.wantErr != nil {
 				if err == nil {
@@ -639,7 +705,7 @@ func TestClearHistory(t *testing.T) {
 
 			service := NewClipboardService(mockStorage, &MockAnalyzer{})
 
-			err := service.ClearHistory(context.Background())
+			err := service.ClearHistory(context.Background(), "")
 
 			if tt.wantErr != nil {
 				if err == nil {
@@ -668,27 +734,61 @@ func TestClearHistory(t *testing.T) {
 func TestGetStats(t *testing.T) {
 	tests := []struct {
 		name            string
-		countResult     int
+		opts            StatsOptions
+		statsResult     *domain.HistoryStats
 		storageError    error
 		wantErr         error
 		wantResult      bool
-		wantCountCalled bool
+		wantAverage     float64
+		wantStatsCalled bool
+		wantBucket      time.Duration
+		wantWindow      time.Duration
 	}{
 		{
-			name:            "Success",
-			countResult:     42,
+			name: "Success",
+			opts: StatsOptions{},
+			statsResult: &domain.HistoryStats{
+				TotalEntries: 4,
+				TotalBytes:   42,
+				CountsByType: map[domain.ContentType]int{domain.ContentTypeText: 3, domain.ContentTypeURL: 1},
+			},
 			storageError:    nil,
 			wantErr:         nil,
 			wantResult:      true,
-			wantCountCalled: true,
+			wantAverage:     10.5,
+			wantStatsCalled: true,
+			wantBucket:      24 * time.Hour,
+			wantWindow:      7 * 24 * time.Hour,
+		},
+		{
+			name:            "HourlyBuckets",
+			opts:            StatsOptions{Bucket: time.Hour},
+			statsResult:     &domain.HistoryStats{},
+			storageError:    nil,
+			wantErr:         nil,
+			wantResult:      true,
+			wantAverage:     0,
+			wantStatsCalled: true,
+			wantBucket:      time.Hour,
+			wantWindow:      7 * time.Hour,
+		},
+		{
+			name:            "TooManyBuckets",
+			opts:            StatsOptions{Bucket: time.Hour, Window: 2000 * time.Hour},
+			statsResult:     nil,
+			storageError:    nil,
+			wantErr:         ErrInvalidStatsWindow,
+			wantResult:      false,
+			wantStatsCalled: false,
 		},
 		{
 			name:            "StorageError",
-			countResult:     0,
+			opts:            StatsOptions{},
+			statsResult:     nil,
 			storageError:    errors.New("database error"),
 			wantErr:         nil,
 			wantResult:      false,
-			wantCountCalled: true,
+			wantStatsCalled: true,
 		},
 	}
 
@@ -697,13 +797,13 @@ func TestGetStats(t *testing.T) {
 			t.Parallel()
 
 			mockStorage := &MockStorage{
-				CountResult: tt.countResult,
-				CountError:  tt.storageError,
+				StatsResult: tt.statsResult,
+				StatsError:  tt.storageError,
 			}
 
 			service := NewClipboardService(mockStorage, &MockAnalyzer{})
 
-			result, err := service.GetStats(context.Background())
+			result, err := service.GetStats(context.Background(), "", tt.opts)
 
 			if tt.wantErr != nil {
 				if err == nil {
@@ -724,9 +824,16 @@ func TestGetStats(t *testing.T) {
 
 			if tt.wantResult {
 				if result == nil {
-					t.Error("expected result, got nil")
-				} else if result.TotalEntries != tt.countResult {
-					t.Errorf("expected TotalEntries=%d, got %d", tt.countResult, result.TotalEntries)
+					t.Fatal("expected result, got nil")
+				}
+				if result.TotalEntries != tt.statsResult.TotalEntries {
+					t.Errorf("expected TotalEntries=%d, got %d", tt.statsResult.TotalEntries, result.TotalEntries)
+				}
+				if result.AverageBytes != tt.wantAverage {
+					t.Errorf("expected AverageBytes=%v, got %v", tt.wantAverage, result.AverageBytes)
+				}
+				if result.Bucket != tt.wantBucket || result.Window != tt.wantWindow {
+					t.Errorf("expected bucket=%v window=%v, got bucket=%v window=%v", tt.wantBucket, tt.wantWindow, result.Bucket, result.Window)
 				}
 			} else {
 				if result != nil {
@@ -734,13 +841,84 @@ func TestGetStats(t *testing.T) {
 				}
 			}
 
-			if mockStorage.CountCalled != tt.wantCountCalled {
-				t.Errorf("expected CountCalled=%v, got %v", tt.wantCountCalled, mockStorage.CountCalled)
+			if mockStorage.StatsCalled != tt.wantStatsCalled {
+				t.Errorf("expected StatsCalled=%v, got %v", tt.wantStatsCalled, mockStorage.StatsCalled)
+			}
+
+			if tt.wantStatsCalled {
+				if got := mockStorage.StatsQuery.Until.Sub(mockStorage.StatsQuery.Since); tt.wantWindow != 0 && got != tt.wantWindow {
+					t.Errorf("expected query window=%v, got %v", tt.wantWindow, got)
+				}
 			}
 		})
 	}
 }
 
+func TestProcessNewEntryCleansURLs(t *testing.T) {
+	mockStorage := &MockStorage{StoreResult: &domain.ClipboardEntry{Id: "1"}}
+	service := NewClipboardService(mockStorage, &MockAnalyzer{
+		Result: &domain.Analysis{
+			Type:     domain.ContentTypeURL,
+			Domain:   "example.com",
+			CleanURL: "https://example.com/a",
+		},
+	})
+
+	_, err := service.ProcessNewEntry(context.Background(), &domain.ClipboardEntry{
+		Content:   "https://Example.com/a/?fbclid=1",
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	stored := mockStorage.StoreCalledWith
+	if stored.Content != "https://example.com/a" {
+		t.Errorf("expected cleaned content, got %q", stored.Content)
+	}
+	if stored.Metadata.OriginalURL != "https://Example.com/a/?fbclid=1" {
+		t.Errorf("expected original URL in metadata, got %q", stored.Metadata.OriginalURL)
+	}
+	if stored.Domain != "example.com" {
+		t.Errorf("expected domain example.com, got %q", stored.Domain)
+	}
+}
+
+func TestGetStatsCountsSensitiveSkips(t *testing.T) {
+	mockStorage := &MockStorage{
+		StatsResult: &domain.HistoryStats{},
+	}
+	mockAnalyzer := &MockAnalyzer{
+		Result: &domain.Analysis{
+			Type:        domain.ContentTypeText,
+			IsSensitive: true,
+			Reason:      "contains password",
+		},
+	}
+
+	service := NewClipboardService(mockStorage, mockAnalyzer)
+
+	for i := 0; i < 3; i++ {
+		service.ProcessNewEntry(context.Background(), &domain.ClipboardEntry{
+			Content:   "password: hunter2",
+			Timestamp: time.Now(),
+		})
+	}
+
+	result, err := service.GetStats(context.Background(), "", StatsOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if result.SensitiveSkipped != 3 {
+		t.Errorf("expected SensitiveSkipped=3, got %d", result.SensitiveSkipped)
+	}
+
+	if result.SensitiveByReason["contains password"] != 3 {
+		t.Errorf("expected 3 skips for reason, got %v", result.SensitiveByReason)
+	}
+}
+
 func TestDeleteOlderThan(t *testing.T) {
 	now := time.Now()
 	cutoff := now.Add(-24 * time.Hour)
@@ -794,7 +972,7 @@ func TestDeleteOlderThan(t *testing.T) {
 
 			service := NewClipboardService(mockStorage, &MockAnalyzer{})
 
-			result, err := service.DeleteOlderThan(context.Background(), tt.cutoff)
+			result, err := service.DeleteOlderThan(context.Background(), "", tt.cutoff)
 
 			if tt.wantErr != nil {
 				if err == nil {
@@ -827,3 +1005,63 @@ func TestDeleteOlderThan(t *testing.T) {
 		})
 	}
 }
+
+func TestRunMaintenance(t *testing.T) {
+	tests := []struct {
+		name         string
+		report       *domain.MaintenanceReport
+		storageError error
+		wantErr      bool
+		wantLastErr  error
+	}{
+		{
+			name:         "Success",
+			report:       &domain.MaintenanceReport{IntegrityOK: true, SizeBefore: 8192, SizeAfter: 4096},
+			storageError: nil,
+			wantErr:      false,
+			wantLastErr:  nil,
+		},
+		{
+			name:         "StorageError",
+			report:       nil,
+			storageError: errors.New("database error"),
+			wantErr:      true,
+			wantLastErr:  ErrNoMaintenanceRun,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := &MockStorage{
+				MaintainResult: tt.report,
+				MaintainError:  tt.storageError,
+			}
+
+			service := NewClipboardService(mockStorage, &MockAnalyzer{})
+
+			if _, err := service.LastMaintenance(context.Background()); !errors.Is(err, ErrNoMaintenanceRun) {
+				t.Fatalf("expected ErrNoMaintenanceRun before first run, got %v", err)
+			}
+
+			report, err := service.RunMaintenance(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunMaintenance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !mockStorage.MaintainCalled {
+				t.Error("expected MaintainCalled=true")
+			}
+
+			last, err := service.LastMaintenance(context.Background())
+			if !errors.Is(err, tt.wantLastErr) {
+				t.Errorf("expected LastMaintenance error %v, got %v", tt.wantLastErr, err)
+			}
+
+			if !tt.wantErr && last != report {
+				t.Errorf("expected last report %v, got %v", report, last)
+			}
+		})
+	}
+}
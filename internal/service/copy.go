@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// ClipboardWriter puts content on a system selection. The daemon passes
+// its monitor, so that the write is not captured again as a new entry.
+type ClipboardWriter interface {
+	WriteSelection(selection domain.Selection, content string) error
+}
+
+// EnableClipboard lets CopyEntry write to the clipboard through w.
+func (s *ClipboardService) EnableClipboard(w ClipboardWriter) {
+	s.clipboard = w
+}
+
+// CopyEntry puts an entry back on the clipboard, or on another selection,
+// and counts it as used. An empty selection means the clipboard.
+func (s *ClipboardService) CopyEntry(ctx context.Context, profile, id, selection string) (*domain.ClipboardEntry, error) {
+	if s.clipboard == nil {
+		return nil, ErrClipboardUnavailable
+	}
+
+	target, ok := domain.ParseSelection(selection)
+	if !ok {
+		return nil, ErrInvalidSelection
+	}
+
+	entry, err := s.GetEntry(ctx, profile, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.clipboard.WriteSelection(target, entry.Content); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrClipboardWrite, err)
+	}
+
+	storage, _, err := s.storageFor(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := storage.MarkUsed(ctx, entry.Id, now); err != nil {
+		return nil, fmt.Errorf("failed to record usage: %w", err)
+	}
+	entry.UseCount++
+	entry.LastUsedAt = now
+
+	return entry, nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+type MockClipboard struct {
+	WriteError error
+	Written    []string
+	Selections []domain.Selection
+}
+
+func (m *MockClipboard) WriteSelection(selection domain.Selection, content string) error {
+	if m.WriteError != nil {
+		return m.WriteError
+	}
+	m.Written = append(m.Written, content)
+	m.Selections = append(m.Selections, selection)
+	return nil
+}
+
+func TestCopyEntry(t *testing.T) {
+	tests := []struct {
+		name          string
+		selection     string
+		clipboard     *MockClipboard
+		getByIdError  error
+		markUsedError error
+		wantErr       error
+		wantWritten   bool
+		wantMarked    bool
+		wantSelection domain.Selection
+	}{
+		{
+			name:          "copies and counts the use",
+			clipboard:     &MockClipboard{},
+			wantWritten:   true,
+			wantMarked:    true,
+			wantSelection: domain.SelectionClipboard,
+		},
+		{
+			name:          "chosen selection",
+			selection:     "Primary",
+			clipboard:     &MockClipboard{},
+			wantWritten:   true,
+			wantMarked:    true,
+			wantSelection: domain.SelectionPrimary,
+		},
+		{
+			name:      "unknown selection",
+			selection: "middle",
+			clipboard: &MockClipboard{},
+			wantErr:   ErrInvalidSelection,
+		},
+		{
+			name:    "clipboard not enabled",
+			wantErr: ErrClipboardUnavailable,
+		},
+		{
+			name:         "entry not found",
+			clipboard:    &MockClipboard{},
+			getByIdError: errors.New("no rows"),
+			wantErr:      ErrNotFound,
+		},
+		{
+			name:      "write fails",
+			clipboard: &MockClipboard{WriteError: errors.New("no display")},
+			wantErr:   ErrClipboardWrite,
+		},
+		{
+			name:          "usage update fails after the write",
+			clipboard:     &MockClipboard{},
+			markUsedError: errors.New("database locked"),
+			wantErr:       errors.New("failed to record usage: database locked"),
+			wantWritten:   true,
+			wantMarked:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := &MockStorage{
+				GetByIdResult: &domain.ClipboardEntry{Id: "7", Content: "hello", UseCount: 2},
+				GetByIdError:  tt.getByIdError,
+				MarkUsedError: tt.markUsedError,
+			}
+			service := NewClipboardService(mockStorage, &MockAnalyzer{})
+			if tt.clipboard != nil {
+				service.EnableClipboard(tt.clipboard)
+			}
+
+			got, err := service.CopyEntry(context.Background(), "", "7", tt.selection)
+
+			if tt.clipboard != nil && (len(tt.clipboard.Written) > 0) != tt.wantWritten {
+				t.Errorf("expected written %v, got %v", tt.wantWritten, tt.clipboard.Written)
+			}
+			if mockStorage.MarkUsedCalled != tt.wantMarked {
+				t.Errorf("expected MarkUsedCalled %v, got %v", tt.wantMarked, mockStorage.MarkUsedCalled)
+			}
+
+			if tt.wantErr != nil {
+				if err == nil || (!errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error()) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if tt.clipboard.Written[0] != "hello" {
+				t.Errorf("expected %q to be written, got %q", "hello", tt.clipboard.Written[0])
+			}
+			if tt.clipboard.Selections[0] != tt.wantSelection {
+				t.Errorf("expected selection %q, got %q", tt.wantSelection, tt.clipboard.Selections[0])
+			}
+			if mockStorage.MarkUsedId != "7" {
+				t.Errorf("expected entry 7 to be marked used, got %q", mockStorage.MarkUsedId)
+			}
+			if got.UseCount != 3 || got.LastUsedAt.IsZero() {
+				t.Errorf("expected the returned entry to reflect the use, got count %d at %s", got.UseCount, got.LastUsedAt)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package service
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
 
 var (
 	// Entry-related errors
@@ -13,12 +17,55 @@ var (
 	ErrInvalidLimit = errors.New("limit must be between 1 and 1000")
 	ErrEmptyQuery   = errors.New("search query cannot be empty")
 
+	// Stats-related errors
+	ErrInvalidStatsWindow = errors.New("stats window must be positive and span at most 1000 buckets")
+
+	// Profile-related errors
+	ErrProfileNotFound    = errors.New("profile not found")
+	ErrProfileExists      = errors.New("profile already exists")
+	ErrInvalidProfileName = errors.New("profile name must be 1-32 lowercase letters, digits, '-' or '_'")
+	ErrProfileInUse       = errors.New("profile is the default or active profile")
+	ErrProfilesDisabled   = errors.New("profiles are not enabled")
+	ErrInvalidRetention   = errors.New("retention max age cannot be negative")
+
+	// Maintenance-related errors
+	ErrMaintenanceInProgress = errors.New("maintenance already in progress")
+	ErrNoMaintenanceRun      = errors.New("no maintenance run recorded")
+
+	// Rule-related errors
+	ErrRulesUnsupported = errors.New("analyzer does not support rules")
+	ErrInvalidRules     = errors.New("invalid rules")
+
+	// Vault-related errors
+	ErrVaultDisabled      = errors.New("vault is not enabled")
+	ErrVaultEntryNotFound = errors.New("vault entry not found or expired")
+
 	// Content-related errors
 	ErrSensitiveContent = errors.New("content contains sensitive data")
+	ErrIgnoredContent   = errors.New("content matches an ignore rule")
+
+	// Clipboard-related errors
+	ErrClipboardUnavailable = errors.New("clipboard writing is not available")
+	ErrClipboardWrite       = errors.New("failed to write to clipboard")
+	ErrInvalidSelection     = errors.New("selection must be clipboard, primary or secondary")
+
+	// Transform-related errors
+	ErrNoTransforms     = errors.New("at least one transform is required")
+	ErrUnknownTransform = errors.New("unknown transform")
+	ErrTransformFailed  = errors.New("transform failed")
+
+	// Pause-related errors
+	ErrInvalidPause = errors.New("pause duration cannot be negative")
 )
 
+// SensitiveContentError reports why an entry was blocked. Reason is the
+// name of the matching rule; VaultId is set when the entry was kept in the
+// vault instead.
 type SensitiveContentError struct {
-	Reason string
+	Reason     string
+	Severity   domain.Severity
+	Confidence domain.Confidence
+	VaultId    string
 }
 
 func (e *SensitiveContentError) Error() string {
@@ -28,3 +75,16 @@ func (e *SensitiveContentError) Error() string {
 func (e *SensitiveContentError) Is(target error) bool {
 	return target == ErrSensitiveContent
 }
+
+// IgnoredContentError reports which ignore rule dropped an entry.
+type IgnoredContentError struct {
+	Rule string
+}
+
+func (e *IgnoredContentError) Error() string {
+	return "ignored content: " + e.Rule
+}
+
+func (e *IgnoredContentError) Is(target error) bool {
+	return target == ErrIgnoredContent
+}
@@ -0,0 +1,21 @@
+package service
+
+import "github.com/geodask/clipboard-manager/internal/domain"
+
+// IgnoreRules picks out captures that should never be recorded. Match
+// returns the name of the rule that applies, or "".
+type IgnoreRules interface {
+	Match(entry *domain.ClipboardEntry) string
+}
+
+// EnableIgnoreRules makes ProcessNewEntry drop entries matched by rules
+// before they are analyzed.
+func (s *ClipboardService) EnableIgnoreRules(rules IgnoreRules) {
+	s.ignore = rules
+}
+
+func (s *ClipboardService) recordIgnored(rule string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ignoredByRule[rule]++
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+type MockIgnoreRules struct {
+	Rules map[string]string // content -> rule
+}
+
+func (m *MockIgnoreRules) Match(entry *domain.ClipboardEntry) string {
+	return m.Rules[entry.Content]
+}
+
+func TestProcessNewEntryIgnored(t *testing.T) {
+	mockStorage := &MockStorage{
+		StoreResult: &domain.ClipboardEntry{Id: "1"},
+		StatsResult: &domain.HistoryStats{},
+	}
+
+	// The analyzer would block everything, so only ignored entries get
+	// past it without a SensitiveContentError.
+	service := NewClipboardService(mockStorage, &MockAnalyzer{
+		Result: &domain.Analysis{IsSensitive: true, Reason: "password"},
+	})
+	service.EnableIgnoreRules(&MockIgnoreRules{Rules: map[string]string{
+		"123456": "pattern:^\\d{6}$",
+		"   ":    "whitespace",
+	}})
+
+	for _, content := range []string{"123456", "123456", "   ", "hunter2"} {
+		_, err := service.ProcessNewEntry(context.Background(), &domain.ClipboardEntry{
+			Content:   content,
+			Timestamp: time.Now(),
+		})
+
+		var ignoredErr *IgnoredContentError
+		switch content {
+		case "hunter2":
+			if !errors.Is(err, ErrSensitiveContent) {
+				t.Errorf("expected %q to reach the analyzer, got %v", content, err)
+			}
+		default:
+			if !errors.As(err, &ignoredErr) || !errors.Is(err, ErrIgnoredContent) {
+				t.Errorf("expected %q to be ignored, got %v", content, err)
+			}
+		}
+	}
+
+	if mockStorage.StoreCalled {
+		t.Error("expected ignored entries not to be stored")
+	}
+
+	stats, err := service.GetStats(context.Background(), "", StatsOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if stats.IgnoredSkipped != 3 {
+		t.Errorf("expected IgnoredSkipped=3, got %d", stats.IgnoredSkipped)
+	}
+	if stats.IgnoredByRule["pattern:^\\d{6}$"] != 2 || stats.IgnoredByRule["whitespace"] != 1 {
+		t.Errorf("unexpected ignored counts %v", stats.IgnoredByRule)
+	}
+	if stats.SensitiveSkipped != 1 {
+		t.Errorf("expected ignored entries not to count as sensitive, got %d", stats.SensitiveSkipped)
+	}
+}
@@ -0,0 +1,22 @@
+package service
+
+import "github.com/geodask/clipboard-manager/internal/domain"
+
+// MonitorReporter reports how each selection is being watched.
+type MonitorReporter interface {
+	Status() []domain.MonitorStatus
+}
+
+// EnableMonitorStatus adds the monitor's status to GetStats.
+func (s *ClipboardService) EnableMonitorStatus(m MonitorReporter) {
+	s.monitor = m
+}
+
+// MonitorStatus returns the status of each monitored selection, or nil when
+// no monitor is enabled.
+func (s *ClipboardService) MonitorStatus() []domain.MonitorStatus {
+	if s.monitor == nil {
+		return nil
+	}
+	return s.monitor.Status()
+}
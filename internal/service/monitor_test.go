@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+type MockMonitor struct {
+	Statuses []domain.MonitorStatus
+}
+
+func (m *MockMonitor) Status() []domain.MonitorStatus {
+	return m.Statuses
+}
+
+func TestGetStatsMonitorStatus(t *testing.T) {
+	service := NewClipboardService(&MockStorage{StatsResult: &domain.HistoryStats{}}, &MockAnalyzer{})
+
+	result, err := service.GetStats(context.Background(), "", StatsOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Monitor != nil {
+		t.Errorf("expected no monitor status before it is enabled, got %+v", result.Monitor)
+	}
+
+	service.EnableMonitorStatus(&MockMonitor{Statuses: []domain.MonitorStatus{
+		{Selection: domain.SelectionClipboard, Backend: "poll", Mode: "poll", Interval: 4 * time.Second, ErrorStreak: 3},
+	}})
+
+	result, err = service.GetStats(context.Background(), "", StatsOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.Monitor) != 1 || result.Monitor[0].Interval != 4*time.Second || result.Monitor[0].ErrorStreak != 3 {
+		t.Errorf("expected the monitor status in the stats, got %+v", result.Monitor)
+	}
+}
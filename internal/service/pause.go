@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// PauseStore keeps the pause state across daemon restarts.
+type PauseStore interface {
+	LoadPause(ctx context.Context) (domain.PauseState, error)
+	SavePause(ctx context.Context, state domain.PauseState) error
+}
+
+// EnablePauseStore restores a saved pause, unless it has expired, and
+// saves every later change to store.
+func (s *ClipboardService) EnablePauseStore(ctx context.Context, store PauseStore) error {
+	state, err := store.LoadPause(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load pause state: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pauseStore = store
+	s.pause = state.At(time.Now())
+	return nil
+}
+
+// Pause stops the daemon from recording captures for d, or until Resume
+// when d is zero. Entries added through the API are still stored.
+func (s *ClipboardService) Pause(ctx context.Context, d time.Duration) (domain.PauseState, error) {
+	if d < 0 {
+		return domain.PauseState{}, ErrInvalidPause
+	}
+
+	now := time.Now()
+	state := domain.PauseState{Paused: true, Since: now}
+	if d > 0 {
+		state.Until = now.Add(d)
+	}
+
+	return state, s.setPause(ctx, state)
+}
+
+// Resume lifts a pause.
+func (s *ClipboardService) Resume(ctx context.Context) (domain.PauseState, error) {
+	return domain.PauseState{}, s.setPause(ctx, domain.PauseState{})
+}
+
+// PauseState returns the current pause state; a timed pause ends by itself.
+func (s *ClipboardService) PauseState() domain.PauseState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.pause.At(time.Now())
+}
+
+// setPause saves state before applying it, so that a change that cannot be
+// saved does not take effect, and changes are saved in the order applied.
+func (s *ClipboardService) setPause(ctx context.Context, state domain.PauseState) error {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	s.mu.Lock()
+	store := s.pauseStore
+	s.mu.Unlock()
+
+	if store != nil {
+		if err := store.SavePause(ctx, state); err != nil {
+			return fmt.Errorf("failed to save pause state: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.pause = state
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+type MockPauseStore struct {
+	State     domain.PauseState
+	LoadError error
+	SaveError error
+	Saved     []domain.PauseState
+}
+
+func (m *MockPauseStore) LoadPause(ctx context.Context) (domain.PauseState, error) {
+	return m.State, m.LoadError
+}
+
+func (m *MockPauseStore) SavePause(ctx context.Context, state domain.PauseState) error {
+	if m.SaveError != nil {
+		return m.SaveError
+	}
+	m.Saved = append(m.Saved, state)
+	return nil
+}
+
+func TestPause(t *testing.T) {
+	tests := []struct {
+		name       string
+		duration   time.Duration
+		saveError  error
+		wantErr    error
+		wantPaused bool
+		wantUntil  bool
+	}{
+		{name: "timed", duration: 10 * time.Minute, wantPaused: true, wantUntil: true},
+		{name: "until resumed", wantPaused: true},
+		{name: "negative duration", duration: -time.Second, wantErr: ErrInvalidPause},
+		{name: "save fails", duration: time.Minute, saveError: errors.New("disk full")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &MockPauseStore{SaveError: tt.saveError}
+			service := NewClipboardService(&MockStorage{}, &MockAnalyzer{})
+			if err := service.EnablePauseStore(context.Background(), store); err != nil {
+				t.Fatalf("EnablePauseStore() error = %v", err)
+			}
+
+			_, err := service.Pause(context.Background(), tt.duration)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+			} else if (err != nil) != (tt.saveError != nil) {
+				t.Fatalf("expected error only when saving fails, got %v", err)
+			}
+
+			state := service.PauseState()
+			if state.Paused != tt.wantPaused {
+				t.Errorf("expected Paused %v, got %v", tt.wantPaused, state.Paused)
+			}
+			if !state.Until.IsZero() != tt.wantUntil {
+				t.Errorf("expected Until set %v, got %s", tt.wantUntil, state.Until)
+			}
+			if tt.wantPaused && tt.saveError == nil && (len(store.Saved) != 1 || !store.Saved[0].Paused) {
+				t.Errorf("expected the pause to be saved, got %+v", store.Saved)
+			}
+		})
+	}
+}
+
+func TestResume(t *testing.T) {
+	store := &MockPauseStore{}
+	service := NewClipboardService(&MockStorage{}, &MockAnalyzer{})
+	service.EnablePauseStore(context.Background(), store)
+
+	service.Pause(context.Background(), 0)
+	if _, err := service.Resume(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if service.PauseState().Paused {
+		t.Error("expected capture to be resumed")
+	}
+	if len(store.Saved) != 2 || store.Saved[1].Paused {
+		t.Errorf("expected the resume to be saved, got %+v", store.Saved)
+	}
+}
+
+func TestResumeSaveFails(t *testing.T) {
+	store := &MockPauseStore{}
+	service := NewClipboardService(&MockStorage{}, &MockAnalyzer{})
+	service.EnablePauseStore(context.Background(), store)
+
+	service.Pause(context.Background(), 0)
+	store.SaveError = errors.New("disk full")
+	if _, err := service.Resume(context.Background()); err == nil {
+		t.Fatal("expected an error when saving fails")
+	}
+
+	if !service.PauseState().Paused {
+		t.Error("expected capture to stay paused when the resume cannot be saved")
+	}
+}
+
+func TestPauseConcurrent(t *testing.T) {
+	store := &MockPauseStore{}
+	service := NewClipboardService(&MockStorage{}, &MockAnalyzer{})
+	service.EnablePauseStore(context.Background(), store)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				service.Pause(context.Background(), time.Duration(i+1)*time.Minute)
+			} else {
+				service.Resume(context.Background())
+			}
+		}()
+	}
+	wg.Wait()
+
+	last := store.Saved[len(store.Saved)-1]
+	if got := service.PauseState(); got.Paused != last.Paused || !got.Until.Equal(last.Until) {
+		t.Errorf("PauseState() = %+v, want the last saved state %+v", got, last)
+	}
+}
+
+func TestPauseExpires(t *testing.T) {
+	now := time.Now()
+	state := domain.PauseState{Paused: true, Since: now, Until: now.Add(time.Minute)}
+
+	if !state.At(now.Add(59 * time.Second)).Paused {
+		t.Error("expected capture to stay paused before Until")
+	}
+	if state.At(now.Add(time.Minute)).Paused {
+		t.Error("expected the pause to end at Until")
+	}
+	if !(domain.PauseState{Paused: true, Since: now}).At(now.Add(24 * time.Hour)).Paused {
+		t.Error("expected a pause without Until to last")
+	}
+}
+
+func TestEnablePauseStore(t *testing.T) {
+	tests := []struct {
+		name       string
+		store      *MockPauseStore
+		wantErr    bool
+		wantPaused bool
+	}{
+		{
+			name:       "restores a pause",
+			store:      &MockPauseStore{State: domain.PauseState{Paused: true, Since: time.Now(), Until: time.Now().Add(time.Hour)}},
+			wantPaused: true,
+		},
+		{
+			name:  "drops an expired pause",
+			store: &MockPauseStore{State: domain.PauseState{Paused: true, Since: time.Now().Add(-time.Hour), Until: time.Now().Add(-time.Minute)}},
+		},
+		{
+			name:    "load fails",
+			store:   &MockPauseStore{LoadError: errors.New("locked")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewClipboardService(&MockStorage{}, &MockAnalyzer{})
+
+			err := service.EnablePauseStore(context.Background(), tt.store)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if service.PauseState().Paused != tt.wantPaused {
+				t.Errorf("expected Paused %v, got %+v", tt.wantPaused, service.PauseState())
+			}
+		})
+	}
+}
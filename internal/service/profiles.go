@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+	"github.com/geodask/clipboard-manager/internal/storage"
+)
+
+// ProfileStore persists profiles. GetProfile and DeleteProfile report a
+// missing profile with storage.ErrProfileNotFound.
+type ProfileStore interface {
+	ListProfiles(ctx context.Context) ([]*domain.Profile, error)
+	GetProfile(ctx context.Context, name string) (*domain.Profile, error)
+	CreateProfile(ctx context.Context, profile *domain.Profile) (*domain.Profile, error)
+	DeleteProfile(ctx context.Context, name string) error
+	ActiveProfile(ctx context.Context) (string, error)
+	SetActiveProfile(ctx context.Context, name string) error
+}
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// EnableProfiles switches the service from a single history to named
+// profiles. scope must return the entry storage for a given profile name.
+func (s *ClipboardService) EnableProfiles(ctx context.Context, profiles ProfileStore, scope func(profile string) Storage) error {
+	active, err := profiles.ActiveProfile(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load active profile: %w", err)
+	}
+
+	list, err := profiles.ListProfiles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list profiles: %w", err)
+	}
+	names := make(map[string]bool, len(list))
+	for _, profile := range list {
+		names[profile.Name] = true
+	}
+
+	if !names[active] {
+		active = domain.DefaultProfile
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.profiles = profiles
+	s.scope = scope
+	s.activeProfile = active
+	s.profileNames = names
+
+	return nil
+}
+
+// profileStore returns the profile store, or nil while profiles are
+// disabled.
+func (s *ClipboardService) profileStore() ProfileStore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.profiles
+}
+
+func (s *ClipboardService) ActiveProfile() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.activeProfile
+}
+
+// storageFor resolves an empty profile name to the active profile and returns
+// the storage scoped to it.
+func (s *ClipboardService) storageFor(ctx context.Context, profile string) (Storage, string, error) {
+	s.mu.Lock()
+	profiles, scope := s.profiles, s.scope
+	if profile == "" {
+		profile = s.activeProfile
+	}
+	known := s.profileNames[profile]
+	s.mu.Unlock()
+
+	if profiles == nil {
+		if profile != domain.DefaultProfile {
+			return nil, "", ErrProfileNotFound
+		}
+		return s.storage, profile, nil
+	}
+
+	if !known {
+		return nil, "", ErrProfileNotFound
+	}
+
+	return scope(profile), profile, nil
+}
+
+// hasProfile reports whether name is in the cached profile set.
+func (s *ClipboardService) hasProfile(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.profileNames[name]
+}
+
+func (s *ClipboardService) ListProfiles(ctx context.Context) ([]*domain.Profile, error) {
+	store := s.profileStore()
+	if store == nil {
+		return []*domain.Profile{{Name: domain.DefaultProfile, Active: true}}, nil
+	}
+
+	profiles, err := store.ListProfiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+
+	active := s.ActiveProfile()
+	for _, profile := range profiles {
+		profile.Active = profile.Name == active
+	}
+
+	return profiles, nil
+}
+
+func (s *ClipboardService) CreateProfile(ctx context.Context, name string, retentionMaxAge time.Duration) (*domain.Profile, error) {
+	if !profileNamePattern.MatchString(name) {
+		return nil, ErrInvalidProfileName
+	}
+
+	if retentionMaxAge < 0 {
+		return nil, ErrInvalidRetention
+	}
+
+	store := s.profileStore()
+	if store == nil {
+		return nil, ErrProfilesDisabled
+	}
+
+	s.profileMu.Lock()
+	defer s.profileMu.Unlock()
+
+	if s.hasProfile(name) {
+		return nil, ErrProfileExists
+	}
+
+	profile, err := store.CreateProfile(ctx, &domain.Profile{
+		Name:            name,
+		RetentionMaxAge: retentionMaxAge,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create profile: %w", err)
+	}
+
+	s.mu.Lock()
+	s.profileNames[name] = true
+	s.mu.Unlock()
+
+	return profile, nil
+}
+
+func (s *ClipboardService) DeleteProfile(ctx context.Context, name string) error {
+	store := s.profileStore()
+	if store == nil {
+		return ErrProfilesDisabled
+	}
+
+	// Held across the check and the delete, so that the profile cannot be
+	// activated in between.
+	s.profileMu.Lock()
+	defer s.profileMu.Unlock()
+
+	if name == domain.DefaultProfile || name == s.ActiveProfile() {
+		return ErrProfileInUse
+	}
+
+	if err := store.DeleteProfile(ctx, name); err != nil {
+		if errors.Is(err, storage.ErrProfileNotFound) {
+			return ErrProfileNotFound
+		}
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+
+	s.mu.Lock()
+	delete(s.profileNames, name)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// ActivateProfile makes name the profile that new captures are written to.
+func (s *ClipboardService) ActivateProfile(ctx context.Context, name string) (*domain.Profile, error) {
+	store := s.profileStore()
+	if store == nil {
+		return nil, ErrProfilesDisabled
+	}
+
+	s.profileMu.Lock()
+	defer s.profileMu.Unlock()
+
+	profile, err := store.GetProfile(ctx, name)
+	if err != nil {
+		if errors.Is(err, storage.ErrProfileNotFound) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, fmt.Errorf("failed to load profile: %w", err)
+	}
+
+	if err := store.SetActiveProfile(ctx, name); err != nil {
+		return nil, fmt.Errorf("failed to activate profile: %w", err)
+	}
+
+	s.mu.Lock()
+	s.activeProfile = name
+	s.mu.Unlock()
+
+	profile.Active = true
+	return profile, nil
+}
+
+// ApplyRetention deletes expired entries from every profile, using each
+// profile's own max age or defaultMaxAge when it has none.
+func (s *ClipboardService) ApplyRetention(ctx context.Context, defaultMaxAge time.Duration) (int, error) {
+	profiles, err := s.ListProfiles(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	total := 0
+
+	for _, profile := range profiles {
+		maxAge := defaultMaxAge
+		if profile.RetentionMaxAge > 0 {
+			maxAge = profile.RetentionMaxAge
+		}
+
+		deleted, err := s.DeleteOlderThan(ctx, profile.Name, now.Add(-maxAge))
+		if err != nil {
+			return total, fmt.Errorf("retention for profile %s failed: %w", profile.Name, err)
+		}
+		total += deleted
+	}
+
+	return total, nil
+}
+
+// ExpireEntries deletes entries in every profile whose short TTL has ended.
+func (s *ClipboardService) ExpireEntries(ctx context.Context) (int, error) {
+	profiles, err := s.ListProfiles(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	total := 0
+
+	for _, profile := range profiles {
+		storage, _, err := s.storageFor(ctx, profile.Name)
+		if err != nil {
+			return total, err
+		}
+
+		deleted, err := storage.DeleteExpired(ctx, now)
+		if err != nil {
+			return total, fmt.Errorf("expiry for profile %s failed: %w", profile.Name, err)
+		}
+		total += deleted
+	}
+
+	return total, nil
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+	"github.com/geodask/clipboard-manager/internal/storage"
+)
+
+type MockProfileStore struct {
+	Profiles map[string]*domain.Profile
+	Active   string
+
+	Err             error // returned by GetProfile and DeleteProfile when set
+	GetProfileCalls int
+}
+
+func newMockProfileStore(names ...string) *MockProfileStore {
+	store := &MockProfileStore{
+		Profiles: map[string]*domain.Profile{
+			domain.DefaultProfile: {Name: domain.DefaultProfile},
+		},
+		Active: domain.DefaultProfile,
+	}
+	for _, name := range names {
+		store.Profiles[name] = &domain.Profile{Name: name}
+	}
+	return store
+}
+
+func (m *MockProfileStore) ListProfiles(ctx context.Context) ([]*domain.Profile, error) {
+	var profiles []*domain.Profile
+	for _, profile := range m.Profiles {
+		copied := *profile
+		profiles = append(profiles, &copied)
+	}
+	return profiles, nil
+}
+
+func (m *MockProfileStore) GetProfile(ctx context.Context, name string) (*domain.Profile, error) {
+	m.GetProfileCalls++
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	profile, ok := m.Profiles[name]
+	if !ok {
+		return nil, storage.ErrProfileNotFound
+	}
+	copied := *profile
+	return &copied, nil
+}
+
+func (m *MockProfileStore) CreateProfile(ctx context.Context, profile *domain.Profile) (*domain.Profile, error) {
+	m.Profiles[profile.Name] = profile
+	return profile, nil
+}
+
+func (m *MockProfileStore) DeleteProfile(ctx context.Context, name string) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	if _, ok := m.Profiles[name]; !ok {
+		return storage.ErrProfileNotFound
+	}
+	delete(m.Profiles, name)
+	return nil
+}
+
+func (m *MockProfileStore) ActiveProfile(ctx context.Context) (string, error) {
+	return m.Active, nil
+}
+
+func (m *MockProfileStore) SetActiveProfile(ctx context.Context, name string) error {
+	m.Active = name
+	return nil
+}
+
+// newProfiledService returns a service with one MockStorage per profile.
+func newProfiledService(t *testing.T, store *MockProfileStore) (*ClipboardService, map[string]*MockStorage) {
+	t.Helper()
+
+	storages := make(map[string]*MockStorage)
+	for name := range store.Profiles {
+		storages[name] = &MockStorage{}
+	}
+
+	service := NewClipboardService(&MockStorage{}, &MockAnalyzer{})
+	err := service.EnableProfiles(context.Background(), store, func(profile string) Storage {
+		if _, ok := storages[profile]; !ok {
+			storages[profile] = &MockStorage{}
+		}
+		return storages[profile]
+	})
+	if err != nil {
+		t.Fatalf("EnableProfiles() error = %v", err)
+	}
+
+	return service, storages
+}
+
+func TestProcessNewEntryUsesActiveProfile(t *testing.T) {
+	store := newMockProfileStore("work")
+	store.Active = "work"
+
+	service, storages := newProfiledService(t, store)
+	storages["work"].StoreResult = &domain.ClipboardEntry{Id: "1"}
+
+	_, err := service.ProcessNewEntry(context.Background(), &domain.ClipboardEntry{
+		Content:   "captured",
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !storages["work"].StoreCalled {
+		t.Error("expected entry to be stored in the active profile")
+	}
+	if storages[domain.DefaultProfile].StoreCalled {
+		t.Error("expected default profile to be untouched")
+	}
+	if storages["work"].StoreCalledWith.Profile != "work" {
+		t.Errorf("expected entry profile=work, got %q", storages["work"].StoreCalledWith.Profile)
+	}
+}
+
+func TestGetHistoryProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     string
+		wantErr     error
+		wantStorage string
+	}{
+		{
+			name:        "ActiveProfile",
+			profile:     "",
+			wantErr:     nil,
+			wantStorage: domain.DefaultProfile,
+		},
+		{
+			name:        "ExplicitProfile",
+			profile:     "work",
+			wantErr:     nil,
+			wantStorage: "work",
+		},
+		{
+			name:        "UnknownProfile",
+			profile:     "missing",
+			wantErr:     ErrProfileNotFound,
+			wantStorage: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, storages := newProfiledService(t, newMockProfileStore("work"))
+
+			_, err := service.GetHistory(context.Background(), tt.profile, domain.HistoryFilter{}, 10)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			for name, storage := range storages {
+				if storage.GetRecentCalled != (name == tt.wantStorage) {
+					t.Errorf("profile %s: expected GetRecentCalled=%v, got %v", name, name == tt.wantStorage, storage.GetRecentCalled)
+				}
+			}
+		})
+	}
+}
+
+func TestProfileManagement(t *testing.T) {
+	tests := []struct {
+		name    string
+		run     func(s *ClipboardService) error
+		wantErr error
+	}{
+		{
+			name: "CreateProfile",
+			run: func(s *ClipboardService) error {
+				_, err := s.CreateProfile(context.Background(), "personal", 7*24*time.Hour)
+				return err
+			},
+			wantErr: nil,
+		},
+		{
+			name: "CreateInvalidName",
+			run: func(s *ClipboardService) error {
+				_, err := s.CreateProfile(context.Background(), "Not Valid", 0)
+				return err
+			},
+			wantErr: ErrInvalidProfileName,
+		},
+		{
+			name: "CreateExisting",
+			run: func(s *ClipboardService) error {
+				_, err := s.CreateProfile(context.Background(), "work", 0)
+				return err
+			},
+			wantErr: ErrProfileExists,
+		},
+		{
+			name: "ActivateUnknown",
+			run: func(s *ClipboardService) error {
+				_, err := s.ActivateProfile(context.Background(), "missing")
+				return err
+			},
+			wantErr: ErrProfileNotFound,
+		},
+		{
+			name: "DeleteDefault",
+			run: func(s *ClipboardService) error {
+				return s.DeleteProfile(context.Background(), domain.DefaultProfile)
+			},
+			wantErr: ErrProfileInUse,
+		},
+		{
+			name: "DeleteActive",
+			run: func(s *ClipboardService) error {
+				if _, err := s.ActivateProfile(context.Background(), "work"); err != nil {
+					return err
+				}
+				return s.DeleteProfile(context.Background(), "work")
+			},
+			wantErr: ErrProfileInUse,
+		},
+		{
+			name: "DeleteInactive",
+			run: func(s *ClipboardService) error {
+				return s.DeleteProfile(context.Background(), "work")
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, _ := newProfiledService(t, newMockProfileStore("work"))
+
+			err := tt.run(service)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestProfileStoreErrors(t *testing.T) {
+	store := newMockProfileStore("work")
+	service, _ := newProfiledService(t, store)
+	store.Err = errors.New("database is locked")
+
+	if _, err := service.ActivateProfile(context.Background(), "work"); errors.Is(err, ErrProfileNotFound) || !errors.Is(err, store.Err) {
+		t.Errorf("ActivateProfile() error = %v, want the store error", err)
+	}
+	if err := service.DeleteProfile(context.Background(), "work"); errors.Is(err, ErrProfileNotFound) || !errors.Is(err, store.Err) {
+		t.Errorf("DeleteProfile() error = %v, want the store error", err)
+	}
+	if err := service.DeleteProfile(context.Background(), "missing"); errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("DeleteProfile() error = %v, want the store error", err)
+	}
+}
+
+func TestProfileLookupsAreCached(t *testing.T) {
+	store := newMockProfileStore("work")
+	service, _ := newProfiledService(t, store)
+
+	if _, err := service.CreateProfile(context.Background(), "personal", 0); err != nil {
+		t.Fatalf("CreateProfile() error = %v", err)
+	}
+	store.GetProfileCalls = 0
+
+	for _, profile := range []string{"", "work", "personal"} {
+		if _, err := service.GetHistory(context.Background(), profile, domain.HistoryFilter{}, 10); err != nil {
+			t.Errorf("GetHistory(%q) error = %v", profile, err)
+		}
+	}
+	if err := service.DeleteProfile(context.Background(), "work"); err != nil {
+		t.Fatalf("DeleteProfile() error = %v", err)
+	}
+	if _, err := service.GetHistory(context.Background(), "work", domain.HistoryFilter{}, 10); !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("GetHistory() error = %v after delete, want %v", err, ErrProfileNotFound)
+	}
+
+	if store.GetProfileCalls != 0 {
+		t.Errorf("expected no profile lookups, got %d", store.GetProfileCalls)
+	}
+}
+
+func TestActivateAndDeleteProfile(t *testing.T) {
+	for range 50 {
+		store := newMockProfileStore("work")
+		service, _ := newProfiledService(t, store)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			service.ActivateProfile(context.Background(), "work")
+		}()
+		go func() {
+			defer wg.Done()
+			service.DeleteProfile(context.Background(), "work")
+		}()
+		wg.Wait()
+
+		if active := service.ActiveProfile(); active == "work" {
+			if _, ok := store.Profiles["work"]; !ok {
+				t.Fatal("the active profile was deleted")
+			}
+		}
+	}
+}
+
+func TestApplyRetentionPerProfile(t *testing.T) {
+	store := newMockProfileStore("work")
+	store.Profiles["work"].RetentionMaxAge = time.Hour
+
+	service, storages := newProfiledService(t, store)
+	storages[domain.DefaultProfile].DeleteOlderThanResult = 2
+	storages["work"].DeleteOlderThanResult = 3
+
+	before := time.Now()
+	deleted, err := service.ApplyRetention(context.Background(), 24*time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if deleted != 5 {
+		t.Errorf("expected 5 deleted entries, got %d", deleted)
+	}
+
+	if cutoff := storages["work"].DeleteOlderThanCutoff; cutoff.After(before.Add(-time.Hour).Add(time.Second)) || cutoff.Before(before.Add(-time.Hour).Add(-time.Second)) {
+		t.Errorf("expected work cutoff about 1h ago, got %v", cutoff)
+	}
+
+	if cutoff := storages[domain.DefaultProfile].DeleteOlderThanCutoff; cutoff.After(before.Add(-24 * time.Hour).Add(time.Second)) {
+		t.Errorf("expected default cutoff about 24h ago, got %v", cutoff)
+	}
+}
+
+func TestExpireEntries(t *testing.T) {
+	service, storages := newProfiledService(t, newMockProfileStore("work"))
+	storages[domain.DefaultProfile].DeleteExpiredResult = 2
+	storages["work"].DeleteExpiredResult = 1
+
+	deleted, err := service.ExpireEntries(context.Background())
+	if err != nil {
+		t.Fatalf("ExpireEntries() error = %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("expected 3 deleted entries, got %d", deleted)
+	}
+	for name, storage := range storages {
+		if !storage.DeleteExpiredCalled {
+			t.Errorf("profile %s: expected DeleteExpired to be called", name)
+		}
+	}
+}
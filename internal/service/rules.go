@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// RuleEngine is implemented by analyzers whose sensitive-content rules can
+// be listed and reloaded at runtime.
+type RuleEngine interface {
+	Rules() []domain.Rule
+	ReloadRules() error
+}
+
+func (s *ClipboardService) ruleEngine() (RuleEngine, error) {
+	engine, ok := s.analyzer.(RuleEngine)
+	if !ok {
+		return nil, ErrRulesUnsupported
+	}
+	return engine, nil
+}
+
+func (s *ClipboardService) ListRules(ctx context.Context) ([]domain.Rule, error) {
+	engine, err := s.ruleEngine()
+	if err != nil {
+		return nil, err
+	}
+	return engine.Rules(), nil
+}
+
+// ReloadRules re-reads the analyzer's rules file. On failure the previous
+// rules stay in effect.
+func (s *ClipboardService) ReloadRules(ctx context.Context) ([]domain.Rule, error) {
+	engine, err := s.ruleEngine()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := engine.ReloadRules(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRules, err)
+	}
+
+	return engine.Rules(), nil
+}
+
+// storedContent is content as it will be stored: with redactions applied
+// or, for a URL that has none, in its cleaned form. originalURL is set
+// only when the URL changed.
+func storedContent(content string, analysis *domain.Analysis) (stored string, redactions []domain.Redaction, originalURL string) {
+	stored, redactions = redact(content, analysis.Matches)
+	if len(redactions) > 0 || analysis.CleanURL == "" || analysis.CleanURL == strings.TrimSpace(content) {
+		return stored, redactions, ""
+	}
+	return analysis.CleanURL, nil, content
+}
+
+// redact replaces the spans of matches with the redact action by a
+// placeholder naming the rule, and returns where each placeholder ended up.
+// Overlapping spans are merged into the first.
+func redact(content string, matches []domain.Match) (string, []domain.Redaction) {
+	var spans []domain.Match
+	for _, match := range matches {
+		if match.Action == domain.ActionRedact {
+			spans = append(spans, match)
+		}
+	}
+	if len(spans) == 0 {
+		return content, nil
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].Start < spans[j].Start
+	})
+
+	var b strings.Builder
+	var redactions []domain.Redaction
+	last := 0
+	for _, span := range spans {
+		if span.Start < last {
+			last = max(last, span.End)
+			continue
+		}
+		b.WriteString(content[last:span.Start])
+
+		start := b.Len()
+		b.WriteString("«redacted:" + span.Rule + "»")
+		redactions = append(redactions, domain.Redaction{
+			Rule:  span.Rule,
+			Start: start,
+			End:   b.Len(),
+		})
+
+		last = span.End
+	}
+	b.WriteString(content[last:])
+
+	return b.String(), redactions
+}
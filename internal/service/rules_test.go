@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+type MockRuleAnalyzer struct {
+	MockAnalyzer
+	RulesResult []domain.Rule
+	ReloadError error
+	Reloaded    bool
+}
+
+func (m *MockRuleAnalyzer) Rules() []domain.Rule {
+	return m.RulesResult
+}
+
+func (m *MockRuleAnalyzer) ReloadRules() error {
+	m.Reloaded = true
+	return m.ReloadError
+}
+
+func TestProcessNewEntryRedacts(t *testing.T) {
+	content := "user=alice key=ACME-1234 other=ACME-9"
+
+	mockStorage := &MockStorage{StoreResult: &domain.ClipboardEntry{Id: "1"}}
+	mockAnalyzer := &MockAnalyzer{
+		Result: &domain.Analysis{
+			Type: domain.ContentTypeText,
+			Matches: []domain.Match{
+				{Rule: "acme_key", Action: domain.ActionRedact, Start: 15, End: 24},
+				{Rule: "acme_key", Action: domain.ActionRedact, Start: 31, End: 37},
+				{Rule: "acme_prefix", Action: domain.ActionRedact, Start: 15, End: 19},
+			},
+		},
+	}
+
+	service := NewClipboardService(mockStorage, mockAnalyzer)
+
+	_, err := service.ProcessNewEntry(context.Background(), &domain.ClipboardEntry{
+		Content:   content,
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	stored := mockStorage.StoreCalledWith
+	want := "user=alice key=«redacted:acme_key» other=«redacted:acme_key»"
+	if stored.Content != want {
+		t.Errorf("expected content %q, got %q", want, stored.Content)
+	}
+
+	redactions := stored.Metadata.Redactions
+	if len(redactions) != 2 {
+		t.Fatalf("expected 2 redactions, got %+v", redactions)
+	}
+	for _, r := range redactions {
+		if got := stored.Content[r.Start:r.End]; got != "«redacted:acme_key»" {
+			t.Errorf("expected redaction span to cover placeholder, got %q", got)
+		}
+	}
+}
+
+func TestProcessNewEntrySensitiveReason(t *testing.T) {
+	service := NewClipboardService(&MockStorage{}, &MockAnalyzer{
+		Result: &domain.Analysis{
+			IsSensitive: true,
+			Reason:      "acme_token",
+			Severity:    domain.SeverityCritical,
+		},
+	})
+
+	_, err := service.ProcessNewEntry(context.Background(), &domain.ClipboardEntry{Content: "acme_xyz"})
+
+	var sensitiveErr *SensitiveContentError
+	if !errors.As(err, &sensitiveErr) {
+		t.Fatalf("expected SensitiveContentError, got %v", err)
+	}
+	if sensitiveErr.Reason != "acme_token" || sensitiveErr.Severity != domain.SeverityCritical {
+		t.Errorf("expected acme_token/critical, got %s/%s", sensitiveErr.Reason, sensitiveErr.Severity)
+	}
+}
+
+func TestRules(t *testing.T) {
+	rules := []domain.Rule{{Name: "password", Builtin: true}}
+
+	tests := []struct {
+		name     string
+		analyzer Analyzer
+		wantErr  error
+	}{
+		{
+			name:     "rule engine",
+			analyzer: &MockRuleAnalyzer{RulesResult: rules},
+		},
+		{
+			name:     "reload failure",
+			analyzer: &MockRuleAnalyzer{RulesResult: rules, ReloadError: errors.New("bad pattern")},
+			wantErr:  ErrInvalidRules,
+		},
+		{
+			name:     "analyzer without rules",
+			analyzer: &MockAnalyzer{},
+			wantErr:  ErrRulesUnsupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewClipboardService(&MockStorage{}, tt.analyzer)
+
+			got, err := service.ReloadRules(context.Background())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(got) != 1 || got[0].Name != "password" {
+				t.Errorf("expected reloaded rules, got %+v", got)
+			}
+			if !tt.analyzer.(*MockRuleAnalyzer).Reloaded {
+				t.Error("expected analyzer rules to be reloaded")
+			}
+		})
+	}
+}
+
+func TestProcessNewEntryShortTTL(t *testing.T) {
+	mockStorage := &MockStorage{StoreResult: &domain.ClipboardEntry{Id: "1"}}
+	service := NewClipboardService(mockStorage, &MockAnalyzer{
+		Result: &domain.Analysis{
+			Type:   domain.ContentTypeText,
+			Reason: "phone",
+			TTL:    5 * time.Minute,
+			Matches: []domain.Match{
+				{Rule: "phone", Action: domain.ActionShortTTL, Start: 5, End: 17},
+			},
+		},
+	})
+
+	before := time.Now()
+	_, err := service.ProcessNewEntry(context.Background(), &domain.ClipboardEntry{
+		Content:   "call +14155552671",
+		Timestamp: before,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	stored := mockStorage.StoreCalledWith
+	if stored.Content != "call +14155552671" {
+		t.Errorf("expected short-ttl content to be stored unchanged, got %q", stored.Content)
+	}
+	if stored.ExpiresAt.Before(before.Add(5*time.Minute)) || stored.ExpiresAt.After(time.Now().Add(5*time.Minute)) {
+		t.Errorf("expected entry to expire in 5m, got %s", stored.ExpiresAt)
+	}
+}
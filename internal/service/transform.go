@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+	"github.com/geodask/clipboard-manager/internal/transform"
+)
+
+// Transformed is the result of running transforms on an entry. Entry is
+// set when the result was stored as a new entry.
+type Transformed struct {
+	Content string
+	Entry   *domain.ClipboardEntry
+}
+
+// TransformEntry applies the named transforms to an entry in order. With
+// store set, the result goes through ProcessNewEntry like any other capture,
+// so it can still be ignored, blocked or redacted.
+func (s *ClipboardService) TransformEntry(ctx context.Context, profile, id string, names []string, store bool) (*Transformed, error) {
+	if len(names) == 0 {
+		return nil, ErrNoTransforms
+	}
+
+	for _, name := range names {
+		if _, ok := transform.Lookup(name); !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownTransform, name)
+		}
+	}
+
+	entry, err := s.GetEntry(ctx, profile, id)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := transform.Chain(entry.Content, names)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTransformFailed, err)
+	}
+
+	result := &Transformed{Content: content}
+	if !store {
+		return result, nil
+	}
+
+	result.Entry, err = s.ProcessNewEntry(ctx, &domain.ClipboardEntry{
+		Content:   content,
+		Profile:   entry.Profile,
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
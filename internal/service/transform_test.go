@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+func TestTransformEntry(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		transforms   []string
+		store        bool
+		analysis     *domain.Analysis
+		getByIdError error
+		wantErr      error
+		wantContent  string
+		wantStored   bool
+	}{
+		{
+			name:        "chained without storing",
+			content:     "  hello  ",
+			transforms:  []string{"trim", "upper"},
+			wantContent: "HELLO",
+		},
+		{
+			name:        "stored as a new entry",
+			content:     `{"a": 1}`,
+			transforms:  []string{"json_minify"},
+			store:       true,
+			wantContent: `{"a":1}`,
+			wantStored:  true,
+		},
+		{
+			name:       "stored result is still analyzed",
+			content:    "cGFzc3dvcmQ9aHVudGVyMg==",
+			transforms: []string{"base64_decode"},
+			store:      true,
+			analysis:   &domain.Analysis{IsSensitive: true, Reason: "password"},
+			wantErr:    ErrSensitiveContent,
+		},
+		{
+			name:       "no transforms",
+			content:    "hello",
+			transforms: nil,
+			wantErr:    ErrNoTransforms,
+		},
+		{
+			name:       "unknown transform",
+			content:    "hello",
+			transforms: []string{"trim", "reverse"},
+			wantErr:    ErrUnknownTransform,
+		},
+		{
+			name:       "transform fails",
+			content:    "not json",
+			transforms: []string{"json_pretty"},
+			wantErr:    ErrTransformFailed,
+		},
+		{
+			name:         "entry not found",
+			transforms:   []string{"trim"},
+			getByIdError: errors.New("no rows"),
+			wantErr:      ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := &MockStorage{
+				GetByIdResult: &domain.ClipboardEntry{Id: "1", Content: tt.content, Profile: domain.DefaultProfile},
+				GetByIdError:  tt.getByIdError,
+				StoreResult:   &domain.ClipboardEntry{Id: "2"},
+			}
+			service := NewClipboardService(mockStorage, &MockAnalyzer{Result: tt.analysis})
+
+			got, err := service.TransformEntry(context.Background(), "", "1", tt.transforms, tt.store)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				if mockStorage.StoreCalled && !tt.store {
+					t.Error("expected a failed transform not to store anything")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if got.Content != tt.wantContent {
+				t.Errorf("expected content %q, got %q", tt.wantContent, got.Content)
+			}
+			if mockStorage.StoreCalled != tt.wantStored {
+				t.Errorf("expected StoreCalled %v, got %v", tt.wantStored, mockStorage.StoreCalled)
+			}
+			if tt.wantStored {
+				if got.Entry == nil || got.Entry.Id != "2" {
+					t.Errorf("expected the stored entry, got %+v", got.Entry)
+				}
+				if mockStorage.StoreCalledWith.Content != tt.wantContent {
+					t.Errorf("expected %q to be stored, got %q", tt.wantContent, mockStorage.StoreCalledWith.Content)
+				}
+			} else if got.Entry != nil {
+				t.Errorf("expected no entry when not storing, got %+v", got.Entry)
+			}
+		})
+	}
+}
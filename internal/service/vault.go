@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+// Vault holds sensitive entries in memory for a short time instead of
+// dropping them.
+type Vault interface {
+	Put(entry *domain.ClipboardEntry, analysis *domain.Analysis) domain.VaultEntry
+	List() []domain.VaultEntry
+	Reveal(id string) (domain.VaultEntry, string, bool)
+	Delete(id string) bool
+	Purge() int
+}
+
+// EnableVault makes ProcessNewEntry keep blocked entries in v.
+func (s *ClipboardService) EnableVault(v Vault) {
+	s.vault = v
+}
+
+func (s *ClipboardService) ListVault(ctx context.Context) ([]domain.VaultEntry, error) {
+	if s.vault == nil {
+		return nil, ErrVaultDisabled
+	}
+	return s.vault.List(), nil
+}
+
+// RevealVaultEntry returns the content of a vault entry, e.g. to copy it
+// back to the clipboard.
+func (s *ClipboardService) RevealVaultEntry(ctx context.Context, id string) (domain.VaultEntry, string, error) {
+	if s.vault == nil {
+		return domain.VaultEntry{}, "", ErrVaultDisabled
+	}
+
+	entry, content, ok := s.vault.Reveal(id)
+	if !ok {
+		return domain.VaultEntry{}, "", ErrVaultEntryNotFound
+	}
+	return entry, content, nil
+}
+
+// CopyVaultEntry puts a vault entry on the clipboard, or on another
+// selection, through the daemon's clipboard writer, so that the secret is
+// not captured and analyzed again. An empty selection means the clipboard.
+func (s *ClipboardService) CopyVaultEntry(ctx context.Context, id, selection string) (domain.VaultEntry, error) {
+	if s.clipboard == nil {
+		return domain.VaultEntry{}, ErrClipboardUnavailable
+	}
+
+	target, ok := domain.ParseSelection(selection)
+	if !ok {
+		return domain.VaultEntry{}, ErrInvalidSelection
+	}
+
+	entry, content, err := s.RevealVaultEntry(ctx, id)
+	if err != nil {
+		return domain.VaultEntry{}, err
+	}
+
+	if err := s.clipboard.WriteSelection(target, content); err != nil {
+		return domain.VaultEntry{}, fmt.Errorf("%w: %w", ErrClipboardWrite, err)
+	}
+
+	return entry, nil
+}
+
+func (s *ClipboardService) DeleteVaultEntry(ctx context.Context, id string) error {
+	if s.vault == nil {
+		return ErrVaultDisabled
+	}
+	if !s.vault.Delete(id) {
+		return ErrVaultEntryNotFound
+	}
+	return nil
+}
+
+// PurgeVault zeroes every vault entry and returns how many there were.
+func (s *ClipboardService) PurgeVault() int {
+	if s.vault == nil {
+		return 0
+	}
+	return s.vault.Purge()
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+type MockVault struct {
+	Entries  map[string]string
+	PutCalls []*domain.ClipboardEntry
+}
+
+func (m *MockVault) Put(entry *domain.ClipboardEntry, analysis *domain.Analysis) domain.VaultEntry {
+	m.PutCalls = append(m.PutCalls, entry)
+	return domain.VaultEntry{Id: "v1", Reason: analysis.Reason}
+}
+
+func (m *MockVault) List() []domain.VaultEntry {
+	var entries []domain.VaultEntry
+	for id := range m.Entries {
+		entries = append(entries, domain.VaultEntry{Id: id})
+	}
+	return entries
+}
+
+func (m *MockVault) Reveal(id string) (domain.VaultEntry, string, bool) {
+	content, ok := m.Entries[id]
+	return domain.VaultEntry{Id: id}, content, ok
+}
+
+func (m *MockVault) Delete(id string) bool {
+	_, ok := m.Entries[id]
+	delete(m.Entries, id)
+	return ok
+}
+
+func (m *MockVault) Purge() int {
+	n := len(m.Entries)
+	m.Entries = nil
+	return n
+}
+
+func TestProcessNewEntryVaultsSensitiveContent(t *testing.T) {
+	mockStorage := &MockStorage{}
+	mockVault := &MockVault{}
+
+	service := NewClipboardService(mockStorage, &MockAnalyzer{
+		Result: &domain.Analysis{IsSensitive: true, Reason: "password"},
+	})
+	service.EnableVault(mockVault)
+
+	_, err := service.ProcessNewEntry(context.Background(), &domain.ClipboardEntry{
+		Content:   "password=hunter2",
+		Timestamp: time.Now(),
+	})
+
+	var sensitiveErr *SensitiveContentError
+	if !errors.As(err, &sensitiveErr) {
+		t.Fatalf("expected SensitiveContentError, got %v", err)
+	}
+	if sensitiveErr.VaultId != "v1" {
+		t.Errorf("expected vault id v1, got %q", sensitiveErr.VaultId)
+	}
+	if len(mockVault.PutCalls) != 1 {
+		t.Errorf("expected entry to be put in the vault once, got %d", len(mockVault.PutCalls))
+	}
+	if mockStorage.StoreCalled {
+		t.Error("expected sensitive entry not to be stored")
+	}
+}
+
+func TestVaultOperations(t *testing.T) {
+	tests := []struct {
+		name    string
+		vault   *MockVault
+		id      string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "reveal",
+			vault: &MockVault{Entries: map[string]string{"v1": "hunter2"}},
+			id:    "v1",
+			want:  "hunter2",
+		},
+		{
+			name:    "expired entry",
+			vault:   &MockVault{Entries: map[string]string{}},
+			id:      "v1",
+			wantErr: ErrVaultEntryNotFound,
+		},
+		{
+			name:    "vault disabled",
+			id:      "v1",
+			wantErr: ErrVaultDisabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewClipboardService(&MockStorage{}, &MockAnalyzer{})
+			if tt.vault != nil {
+				service.EnableVault(tt.vault)
+			}
+
+			_, content, err := service.RevealVaultEntry(context.Background(), tt.id)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if content != tt.want {
+				t.Errorf("expected content %q, got %q", tt.want, content)
+			}
+
+			if err := service.DeleteVaultEntry(context.Background(), tt.id); err != nil {
+				t.Errorf("expected delete to succeed, got %v", err)
+			}
+			if err := service.DeleteVaultEntry(context.Background(), tt.id); !errors.Is(err, ErrVaultEntryNotFound) {
+				t.Errorf("expected second delete to fail with not found, got %v", err)
+			}
+		})
+	}
+
+	service := NewClipboardService(&MockStorage{}, &MockAnalyzer{})
+	if n := service.PurgeVault(); n != 0 {
+		t.Errorf("expected PurgeVault without a vault to return 0, got %d", n)
+	}
+}
+
+func TestCopyVaultEntry(t *testing.T) {
+	tests := []struct {
+		name          string
+		vault         *MockVault
+		clipboard     *MockClipboard
+		selection     string
+		wantErr       error
+		wantSelection domain.Selection
+	}{
+		{
+			name:          "copies through the clipboard writer",
+			vault:         &MockVault{Entries: map[string]string{"v1": "hunter2"}},
+			clipboard:     &MockClipboard{},
+			wantSelection: domain.SelectionClipboard,
+		},
+		{
+			name:          "chosen selection",
+			vault:         &MockVault{Entries: map[string]string{"v1": "hunter2"}},
+			clipboard:     &MockClipboard{},
+			selection:     "primary",
+			wantSelection: domain.SelectionPrimary,
+		},
+		{
+			name:      "unknown selection",
+			vault:     &MockVault{Entries: map[string]string{"v1": "hunter2"}},
+			clipboard: &MockClipboard{},
+			selection: "middle",
+			wantErr:   ErrInvalidSelection,
+		},
+		{
+			name:      "expired entry",
+			vault:     &MockVault{Entries: map[string]string{}},
+			clipboard: &MockClipboard{},
+			wantErr:   ErrVaultEntryNotFound,
+		},
+		{
+			name:      "vault disabled",
+			clipboard: &MockClipboard{},
+			wantErr:   ErrVaultDisabled,
+		},
+		{
+			name:    "clipboard not enabled",
+			vault:   &MockVault{Entries: map[string]string{"v1": "hunter2"}},
+			wantErr: ErrClipboardUnavailable,
+		},
+		{
+			name:      "write fails",
+			vault:     &MockVault{Entries: map[string]string{"v1": "hunter2"}},
+			clipboard: &MockClipboard{WriteError: errors.New("no display")},
+			wantErr:   ErrClipboardWrite,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewClipboardService(&MockStorage{}, &MockAnalyzer{})
+			if tt.vault != nil {
+				service.EnableVault(tt.vault)
+			}
+			if tt.clipboard != nil {
+				service.EnableClipboard(tt.clipboard)
+			}
+
+			_, err := service.CopyVaultEntry(context.Background(), "v1", tt.selection)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				if tt.clipboard != nil && len(tt.clipboard.Written) != 0 {
+					t.Errorf("expected nothing written, got %q", tt.clipboard.Written)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if len(tt.clipboard.Written) != 1 || tt.clipboard.Written[0] != "hunter2" {
+				t.Fatalf("expected the secret written once, got %q", tt.clipboard.Written)
+			}
+			if tt.clipboard.Selections[0] != tt.wantSelection {
+				t.Errorf("expected selection %q, got %q", tt.wantSelection, tt.clipboard.Selections[0])
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -27,21 +28,37 @@ func (ms *MemoryStorage) Store(ctx context.Context, entry *domain.ClipboardEntry
 	storedEntry := &domain.ClipboardEntry{
 		Id:        id,
 		Content:   entry.Content,
+		Type:      contentTypeOrDefault(entry.Type),
+		Language:  entry.Language,
+		Domain:    entry.Domain,
+		Metadata:  entry.Metadata,
 		Timestamp: entry.Timestamp,
+		ExpiresAt: entry.ExpiresAt,
 	}
 	ms.entries = append(ms.entries, storedEntry)
 	return storedEntry, nil
 }
 
-func (ms *MemoryStorage) GetRecent(ctx context.Context, n int) ([]*domain.ClipboardEntry, error) {
+func (ms *MemoryStorage) GetRecent(ctx context.Context, filter domain.HistoryFilter, n int) ([]*domain.ClipboardEntry, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	if len(ms.entries) < n {
-		return ms.entries, nil
+	var entries []*domain.ClipboardEntry
+	for _, entry := range ms.entries {
+		if filter.Language != "" && entry.Language != filter.Language {
+			continue
+		}
+		if filter.Domain != "" && entry.Domain != filter.Domain && !strings.HasSuffix(entry.Domain, "."+filter.Domain) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) < n {
+		return entries, nil
 	}
-	return ms.entries[len(ms.entries)-n:], nil
+	return entries[len(entries)-n:], nil
 }
 
 func (ms *MemoryStorage) GetByID(ctx context.Context, id string) (*domain.ClipboardEntry, error) {
@@ -71,6 +88,21 @@ func (ms *MemoryStorage) Delete(ctx context.Context, id string) error {
 	return fmt.Errorf("entry not found")
 }
 
+func (ms *MemoryStorage) MarkUsed(ctx context.Context, id string, at time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, entry := range ms.entries {
+		if entry.Id == id {
+			entry.UseCount++
+			entry.LastUsedAt = at
+			return nil
+		}
+	}
+	return fmt.Errorf("entry not found")
+}
+
 func (ms *MemoryStorage) Search(ctx context.Context, query string, limit int) ([]*domain.ClipboardEntry, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -122,6 +154,84 @@ func (ms *MemoryStorage) DeleteOlderThan(ctx context.Context, cutoff time.Time)
 	return deleted, nil
 }
 
+func (ms *MemoryStorage) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var newEntries []*domain.ClipboardEntry
+	deleted := 0
+
+	for _, entry := range ms.entries {
+		if !entry.ExpiresAt.IsZero() && !entry.ExpiresAt.After(now) {
+			deleted++
+		} else {
+			newEntries = append(newEntries, entry)
+		}
+	}
+
+	ms.entries = newEntries
+	return deleted, nil
+}
+
+func (ms *MemoryStorage) Stats(ctx context.Context, query domain.StatsQuery) (*domain.HistoryStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := &domain.HistoryStats{
+		TotalEntries: len(ms.entries),
+		CountsByType: make(map[domain.ContentType]int),
+	}
+	h := newHistogram(query)
+
+	var sizes []domain.EntrySize
+	for _, entry := range ms.entries {
+		size := len(entry.Content)
+		stats.TotalBytes += int64(size)
+		stats.CountsByType[entry.Type]++
+
+		if stats.Oldest.IsZero() || entry.Timestamp.Before(stats.Oldest) {
+			stats.Oldest = entry.Timestamp
+		}
+		if entry.Timestamp.After(stats.Newest) {
+			stats.Newest = entry.Timestamp
+		}
+
+		h.add(entry.Timestamp)
+
+		sizes = append(sizes, domain.EntrySize{
+			Id:        entry.Id,
+			Type:      entry.Type,
+			Size:      size,
+			Timestamp: entry.Timestamp,
+		})
+	}
+
+	sort.SliceStable(sizes, func(i, j int) bool {
+		return sizes[i].Size > sizes[j].Size
+	})
+	if len(sizes) > query.Largest {
+		sizes = sizes[:max(query.Largest, 0)]
+	}
+	stats.Largest = sizes
+	stats.Activity = h.buckets
+	stats.DatabaseBytes = stats.TotalBytes
+
+	return stats, nil
+}
+
+func (ms *MemoryStorage) Maintain(ctx context.Context) (*domain.MaintenanceReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return &domain.MaintenanceReport{
+		StartedAt:   time.Now(),
+		IntegrityOK: true,
+	}, nil
+}
+
 func contains(content, query string) bool {
 	return strings.Contains(strings.ToLower(content), strings.ToLower(query))
 }
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+const pauseKey = "capture_paused"
+
+type pauseSetting struct {
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until,omitzero"`
+}
+
+// LoadPause returns the persisted pause state. Capture is not paused when
+// none has been saved.
+func (s *SQLiteStorage) LoadPause(ctx context.Context) (domain.PauseState, error) {
+	var value string
+	err := s.reader.QueryRowContext(ctx,
+		"SELECT value FROM settings WHERE key = ?",
+		pauseKey,
+	).Scan(&value)
+
+	if err == sql.ErrNoRows {
+		return domain.PauseState{}, nil
+	}
+	if err != nil {
+		return domain.PauseState{}, err
+	}
+
+	var setting pauseSetting
+	if err := json.Unmarshal([]byte(value), &setting); err != nil {
+		return domain.PauseState{}, err
+	}
+	return domain.PauseState{Paused: true, Since: setting.Since, Until: setting.Until}, nil
+}
+
+// SavePause persists state, removing any saved pause once capture resumes.
+func (s *SQLiteStorage) SavePause(ctx context.Context, state domain.PauseState) error {
+	if !state.Paused {
+		_, err := s.writer.ExecContext(ctx, "DELETE FROM settings WHERE key = ?", pauseKey)
+		return err
+	}
+
+	value, err := json.Marshal(pauseSetting{Since: state.Since, Until: state.Until})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.writer.ExecContext(ctx,
+		"INSERT INTO settings (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value",
+		pauseKey, string(value),
+	)
+	return err
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+const activeProfileKey = "active_profile"
+
+// ErrProfileNotFound is returned by GetProfile and DeleteProfile for a
+// profile that does not exist.
+var ErrProfileNotFound = errors.New("profile not found")
+
+func (s *SQLiteStorage) migrateProfiles() error {
+	_, err := s.writer.Exec(`
+		CREATE TABLE IF NOT EXISTS profiles (
+			name TEXT PRIMARY KEY,
+			retention_max_age INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.writer.Exec(`
+		CREATE TABLE IF NOT EXISTS settings (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.writer.Exec(
+		"INSERT OR IGNORE INTO profiles (name, retention_max_age, created_at) VALUES (?, 0, ?)",
+		domain.DefaultProfile, time.Now(),
+	)
+	return err
+}
+
+func (s *SQLiteStorage) ListProfiles(ctx context.Context) ([]*domain.Profile, error) {
+	rows, err := s.reader.QueryContext(ctx,
+		"SELECT name, retention_max_age, created_at FROM profiles ORDER BY name",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []*domain.Profile
+
+	for rows.Next() {
+		var profile domain.Profile
+		var retention int64
+		if err := rows.Scan(&profile.Name, &retention, &profile.CreatedAt); err != nil {
+			return nil, err
+		}
+		profile.RetentionMaxAge = time.Duration(retention)
+		profiles = append(profiles, &profile)
+	}
+
+	return profiles, rows.Err()
+}
+
+func (s *SQLiteStorage) GetProfile(ctx context.Context, name string) (*domain.Profile, error) {
+	profile := domain.Profile{Name: name}
+	var retention int64
+
+	err := s.reader.QueryRowContext(ctx,
+		"SELECT retention_max_age, created_at FROM profiles WHERE name = ?",
+		name,
+	).Scan(&retention, &profile.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrProfileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	profile.RetentionMaxAge = time.Duration(retention)
+	return &profile, nil
+}
+
+func (s *SQLiteStorage) CreateProfile(ctx context.Context, profile *domain.Profile) (*domain.Profile, error) {
+	created := &domain.Profile{
+		Name:            profile.Name,
+		RetentionMaxAge: profile.RetentionMaxAge,
+		CreatedAt:       time.Now(),
+	}
+
+	_, err := s.writer.ExecContext(ctx,
+		"INSERT INTO profiles (name, retention_max_age, created_at) VALUES (?, ?, ?)",
+		created.Name, int64(created.RetentionMaxAge), created.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// DeleteProfile removes a profile together with all of its entries.
+func (s *SQLiteStorage) DeleteProfile(ctx context.Context, name string) error {
+	tx, err := s.writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM clipboard_history WHERE profile = ?", name); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM profiles WHERE name = ?", name)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrProfileNotFound
+	}
+
+	return tx.Commit()
+}
+
+// ActiveProfile returns the persisted active profile, or the default profile
+// when none has been selected yet.
+func (s *SQLiteStorage) ActiveProfile(ctx context.Context) (string, error) {
+	var name string
+	err := s.reader.QueryRowContext(ctx,
+		"SELECT value FROM settings WHERE key = ?",
+		activeProfileKey,
+	).Scan(&name)
+
+	if err == sql.ErrNoRows {
+		return domain.DefaultProfile, nil
+	}
+
+	return name, err
+}
+
+func (s *SQLiteStorage) SetActiveProfile(ctx context.Context, name string) error {
+	_, err := s.writer.ExecContext(ctx,
+		"INSERT INTO settings (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value",
+		activeProfileKey, name,
+	)
+	return err
+}
@@ -3,44 +3,271 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/geodask/clipboard-manager/internal/config"
 	"github.com/geodask/clipboard-manager/internal/domain"
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// SQLiteStorage keeps two pools on the same WAL-mode database: a single
+// writer connection that serialises inserts and deletes, and a pool of
+// read-only connections that can run alongside it.
+//
+// Every entry operation is scoped to one profile. The value returned by
+// NewSQLiteStorage uses the default profile; WithProfile derives views onto
+// other profiles that share the same connections.
 type SQLiteStorage struct {
-	db *sql.DB
+	writer  *sql.DB
+	reader  *sql.DB
+	profile string
+
+	insertStmt    *sql.Stmt
+	getRecentStmt *sql.Stmt
+	getByIdStmt   *sql.Stmt
+	searchStmt    *sql.Stmt
 }
 
-func NewSQLiteStorage(dbPath string) (*SQLiteStorage, error) {
-	db, err := sql.Open("sqlite3", dbPath+"?_txn=immediate&parseTime=true")
+func NewSQLiteStorage(cfg config.DatabaseConfig) (*SQLiteStorage, error) {
+	params := fmt.Sprintf("_journal_mode=WAL&_synchronous=NORMAL&_busy_timeout=%d", cfg.BusyTimeout.Milliseconds())
+
+	// auto_vacuum only takes effect on new databases and has to be applied
+	// before the switch to WAL, so it goes in the DSN. Existing databases are
+	// converted by the first full VACUUM in Maintain.
+	writer, err := sql.Open("sqlite3", "file:"+cfg.Path+"?"+params+"&_auto_vacuum=incremental&_txlock=immediate")
+	if err != nil {
+		return nil, err
+	}
+	writer.SetMaxOpenConns(1)
+
+	s := &SQLiteStorage{writer: writer, profile: domain.DefaultProfile}
+
+	if err := s.migrate(); err != nil {
+		writer.Close()
+		return nil, err
+	}
+
+	reader, err := sql.Open("sqlite3", "file:"+cfg.Path+"?"+params+"&_query_only=true")
 	if err != nil {
+		writer.Close()
 		return nil, err
 	}
+	reader.SetMaxOpenConns(max(cfg.MaxReadConns, 1))
+	reader.SetMaxIdleConns(max(cfg.MaxReadConns, 1))
+	s.reader = reader
 
-	_, err = db.Exec(`
+	if err := s.prepare(); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *SQLiteStorage) migrate() error {
+	_, err := s.writer.Exec(`
 		CREATE TABLE IF NOT EXISTS clipboard_history (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			content TEXT NOT NULL,
 			timestamp DATETIME NOT NULL
 		)
 	`)
+	if err != nil {
+		return err
+	}
+
+	if err := ensureColumn(s.writer, "clipboard_history", "content_type", "TEXT NOT NULL DEFAULT 'text'"); err != nil {
+		return err
+	}
+
+	_, err = s.writer.Exec("CREATE INDEX IF NOT EXISTS idx_clipboard_history_timestamp ON clipboard_history (timestamp)")
+	if err != nil {
+		return err
+	}
+
+	if err := ensureColumn(s.writer, "clipboard_history", "profile", "TEXT NOT NULL DEFAULT '"+domain.DefaultProfile+"'"); err != nil {
+		return err
+	}
+
+	_, err = s.writer.Exec("CREATE INDEX IF NOT EXISTS idx_clipboard_history_profile_timestamp ON clipboard_history (profile, timestamp)")
+	if err != nil {
+		return err
+	}
+
+	if err := ensureColumn(s.writer, "clipboard_history", "metadata", "TEXT NOT NULL DEFAULT '{}'"); err != nil {
+		return err
+	}
+
+	if err := ensureColumn(s.writer, "clipboard_history", "language", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+
+	_, err = s.writer.Exec("CREATE INDEX IF NOT EXISTS idx_clipboard_history_profile_language ON clipboard_history (profile, language, timestamp)")
+	if err != nil {
+		return err
+	}
+
+	if err := ensureColumn(s.writer, "clipboard_history", "expires_at", "DATETIME"); err != nil {
+		return err
+	}
 
+	_, err = s.writer.Exec("CREATE INDEX IF NOT EXISTS idx_clipboard_history_expires_at ON clipboard_history (expires_at) WHERE expires_at IS NOT NULL")
 	if err != nil {
-		db.Close()
+		return err
+	}
+
+	if err := ensureColumn(s.writer, "clipboard_history", "domain", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+
+	_, err = s.writer.Exec("CREATE INDEX IF NOT EXISTS idx_clipboard_history_profile_domain ON clipboard_history (profile, domain, timestamp)")
+	if err != nil {
+		return err
+	}
+
+	if err := ensureColumn(s.writer, "clipboard_history", "use_count", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+
+	if err := ensureColumn(s.writer, "clipboard_history", "last_used_at", "DATETIME"); err != nil {
+		return err
+	}
+
+	return s.migrateProfiles()
+}
+
+// WithProfile returns a view of the same database scoped to another profile.
+// The view shares connections with s and must not be closed separately.
+func (s *SQLiteStorage) WithProfile(profile string) *SQLiteStorage {
+	scoped := *s
+	scoped.profile = profile
+	return &scoped
+}
+
+const entryColumns = "id, content, content_type, language, domain, profile, metadata, timestamp, expires_at, use_count, last_used_at"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEntry(row rowScanner) (*domain.ClipboardEntry, error) {
+	var id int64
+	var content string
+	var contentType string
+	var language string
+	var host string
+	var profile string
+	var metadata string
+	var timestamp time.Time
+	var expiresAt sql.NullTime
+	var useCount int
+	var lastUsedAt sql.NullTime
+	if err := row.Scan(&id, &content, &contentType, &language, &host, &profile, &metadata, &timestamp, &expiresAt, &useCount, &lastUsedAt); err != nil {
 		return nil, err
 	}
 
-	return &SQLiteStorage{db: db}, nil
+	entry := &domain.ClipboardEntry{
+		Id:         strconv.FormatInt(id, 10),
+		Content:    content,
+		Type:       domain.ContentType(contentType),
+		Language:   language,
+		Domain:     host,
+		Profile:    profile,
+		Timestamp:  timestamp,
+		ExpiresAt:  expiresAt.Time,
+		UseCount:   useCount,
+		LastUsedAt: lastUsedAt.Time,
+	}
+	if err := json.Unmarshal([]byte(metadata), &entry.Metadata); err != nil {
+		return nil, fmt.Errorf("invalid metadata for entry %d: %w", id, err)
+	}
+
+	return entry, nil
+}
+
+// prepare compiles the statements used on every poll and every list or
+// search request.
+func (s *SQLiteStorage) prepare() error {
+	var err error
+
+	s.insertStmt, err = s.writer.Prepare(
+		"INSERT INTO clipboard_history (content, content_type, language, domain, profile, metadata, timestamp, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+	)
+	if err != nil {
+		return err
+	}
+
+	// Subdomains are matched by suffix with substr rather than LIKE, so
+	// that % and _ in the filter are not wildcards.
+	s.getRecentStmt, err = s.reader.Prepare(
+		"SELECT " + entryColumns + " FROM clipboard_history WHERE profile = ? AND (? = '' OR language = ?) AND (? = '' OR domain = ? OR substr(domain, -length(?) - 1) = '.' || ?) ORDER BY timestamp DESC LIMIT ?",
+	)
+	if err != nil {
+		return err
+	}
+
+	s.getByIdStmt, err = s.reader.Prepare(
+		"SELECT " + entryColumns + " FROM clipboard_history WHERE id = ? AND profile = ?",
+	)
+	if err != nil {
+		return err
+	}
+
+	s.searchStmt, err = s.reader.Prepare(
+		"SELECT " + entryColumns + " FROM clipboard_history WHERE profile = ? AND content LIKE ? ORDER BY timestamp DESC LIMIT ?",
+	)
+	return err
+}
+
+// ensureColumn adds a column to an existing table when it is missing, so that
+// databases created by older versions keep working.
+func ensureColumn(db *sql.DB, table, column, definition string) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	if err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
+
+	return nil
 }
 
 func (s *SQLiteStorage) Store(ctx context.Context, entry *domain.ClipboardEntry) (*domain.ClipboardEntry, error) {
-	result, err := s.db.ExecContext(ctx,
-		"INSERT INTO clipboard_history (content, timestamp) VALUES (?, ?)",
-		entry.Content, entry.Timestamp,
+	metadata, err := json.Marshal(entry.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt sql.NullTime
+	if !entry.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: entry.ExpiresAt, Valid: true}
+	}
+
+	result, err := s.insertStmt.ExecContext(ctx,
+		entry.Content, contentTypeOrDefault(entry.Type), entry.Language, entry.Domain, s.profile, string(metadata), entry.Timestamp, expiresAt,
 	)
 	if err != nil {
 		return nil, err
@@ -53,13 +280,20 @@ func (s *SQLiteStorage) Store(ctx context.Context, entry *domain.ClipboardEntry)
 	return &domain.ClipboardEntry{
 		Id:        strconv.FormatInt(id, 10),
 		Content:   entry.Content,
+		Type:      contentTypeOrDefault(entry.Type),
+		Language:  entry.Language,
+		Domain:    entry.Domain,
+		Profile:   s.profile,
+		Metadata:  entry.Metadata,
 		Timestamp: entry.Timestamp,
+		ExpiresAt: entry.ExpiresAt,
 	}, nil
 }
 
-func (s *SQLiteStorage) GetRecent(ctx context.Context, n int) ([]*domain.ClipboardEntry, error) {
-	rows, err := s.db.QueryContext(ctx,
-		"SELECT id, content, timestamp FROM clipboard_history ORDER BY timestamp DESC LIMIT ?",
+func (s *SQLiteStorage) GetRecent(ctx context.Context, filter domain.HistoryFilter, n int) ([]*domain.ClipboardEntry, error) {
+	rows, err := s.getRecentStmt.QueryContext(ctx, s.profile,
+		filter.Language, filter.Language,
+		filter.Domain, filter.Domain, filter.Domain, filter.Domain,
 		n,
 	)
 	if err != nil {
@@ -74,17 +308,15 @@ func (s *SQLiteStorage) GetRecent(ctx context.Context, n int) ([]*domain.Clipboa
 			return nil, err
 		}
 
-		var id int64
-		var content string
-		var timestamp time.Time
-		if err := rows.Scan(&id, &content, &timestamp); err != nil {
+		entry, err := scanEntry(rows)
+		if err != nil {
 			return nil, err
 		}
-		entries = append(entries, &domain.ClipboardEntry{
-			Id:        strconv.FormatInt(id, 10),
-			Content:   content,
-			Timestamp: timestamp,
-		})
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
 	return entries, nil
@@ -96,13 +328,7 @@ func (s *SQLiteStorage) GetById(ctx context.Context, id string) (*domain.Clipboa
 		return nil, fmt.Errorf("invalid ID format: %w", err)
 	}
 
-	var content string
-	var timestamp time.Time
-
-	err = s.db.QueryRowContext(ctx,
-		"SELECT content, timestamp FROM clipboard_history WHERE id = ?",
-		idInt,
-	).Scan(&content, &timestamp)
+	entry, err := scanEntry(s.getByIdStmt.QueryRowContext(ctx, idInt, s.profile))
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("entry not found")
@@ -111,11 +337,7 @@ func (s *SQLiteStorage) GetById(ctx context.Context, id string) (*domain.Clipboa
 		return nil, err
 	}
 
-	return &domain.ClipboardEntry{
-		Id:        id,
-		Content:   content,
-		Timestamp: timestamp,
-	}, nil
+	return entry, nil
 
 }
 
@@ -125,9 +347,9 @@ func (s *SQLiteStorage) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("invalid ID format: %w", err)
 	}
 
-	result, err := s.db.ExecContext(ctx,
-		"DELETE FROM clipboard_history WHERE id = ?",
-		idInt,
+	result, err := s.writer.ExecContext(ctx,
+		"DELETE FROM clipboard_history WHERE id = ? AND profile = ?",
+		idInt, s.profile,
 	)
 
 	if err != nil {
@@ -146,12 +368,36 @@ func (s *SQLiteStorage) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *SQLiteStorage) Search(ctx context.Context, query string, limit int) ([]*domain.ClipboardEntry, error) {
-	rows, err := s.db.QueryContext(ctx,
-		"SELECT id, content, timestamp FROM clipboard_history WHERE content LIKE ? ORDER BY timestamp DESC LIMIT ?",
-		"%"+query+"%",
-		limit,
+// MarkUsed records that an entry was copied back to the clipboard at the
+// given time.
+func (s *SQLiteStorage) MarkUsed(ctx context.Context, id string, at time.Time) error {
+	idInt, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ID format: %w", err)
+	}
+
+	result, err := s.writer.ExecContext(ctx,
+		"UPDATE clipboard_history SET use_count = use_count + 1, last_used_at = ? WHERE id = ? AND profile = ?",
+		at, idInt, s.profile,
 	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("entry not found")
+	}
+
+	return nil
+}
+
+func (s *SQLiteStorage) Search(ctx context.Context, query string, limit int) ([]*domain.ClipboardEntry, error) {
+	rows, err := s.searchStmt.QueryContext(ctx, s.profile, "%"+query+"%", limit)
 	if err != nil {
 		return nil, err
 	}
@@ -160,17 +406,11 @@ func (s *SQLiteStorage) Search(ctx context.Context, query string, limit int) ([]
 	var entries []*domain.ClipboardEntry
 
 	for rows.Next() {
-		var id int64
-		var content string
-		var timestamp time.Time
-		if err := rows.Scan(&id, &content, &timestamp); err != nil {
+		entry, err := scanEntry(rows)
+		if err != nil {
 			return nil, err
 		}
-		entries = append(entries, &domain.ClipboardEntry{
-			Id:        strconv.FormatInt(id, 10),
-			Content:   content,
-			Timestamp: timestamp,
-		})
+		entries = append(entries, entry)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -182,22 +422,41 @@ func (s *SQLiteStorage) Search(ctx context.Context, query string, limit int) ([]
 
 func (s *SQLiteStorage) Count(ctx context.Context) (int, error) {
 	var count int
-	err := s.db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM clipboard_history",
+	err := s.reader.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM clipboard_history WHERE profile = ?",
+		s.profile,
 	).Scan(&count)
 
 	return count, err
 }
 
 func (s *SQLiteStorage) Clear(ctx context.Context) error {
-	_, err := s.db.ExecContext(ctx, "DELETE FROM clipboard_history")
+	_, err := s.writer.ExecContext(ctx, "DELETE FROM clipboard_history WHERE profile = ?", s.profile)
 	return err
 }
 
 func (s *SQLiteStorage) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int, error) {
-	result, err := s.db.ExecContext(ctx,
-		"DELETE FROM clipboard_history WHERE timestamp < ?",
-		cutoff,
+	result, err := s.writer.ExecContext(ctx,
+		"DELETE FROM clipboard_history WHERE profile = ? AND timestamp < ?",
+		s.profile, cutoff,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rows), nil
+}
+
+// DeleteExpired removes entries whose short TTL ended before now.
+func (s *SQLiteStorage) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	result, err := s.writer.ExecContext(ctx,
+		"DELETE FROM clipboard_history WHERE profile = ? AND expires_at IS NOT NULL AND expires_at <= ?",
+		s.profile, now,
 	)
 	if err != nil {
 		return 0, err
@@ -211,6 +470,244 @@ func (s *SQLiteStorage) DeleteOlderThan(ctx context.Context, cutoff time.Time) (
 	return int(rows), nil
 }
 
+func (s *SQLiteStorage) Stats(ctx context.Context, query domain.StatsQuery) (*domain.HistoryStats, error) {
+	stats := &domain.HistoryStats{
+		CountsByType: make(map[domain.ContentType]int),
+	}
+
+	err := s.reader.QueryRowContext(ctx,
+		"SELECT COUNT(*), COALESCE(SUM(length(CAST(content AS BLOB))), 0) FROM clipboard_history WHERE profile = ?",
+		s.profile,
+	).Scan(&stats.TotalEntries, &stats.TotalBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.reader.QueryContext(ctx,
+		"SELECT content_type, COUNT(*) FROM clipboard_history WHERE profile = ? GROUP BY content_type",
+		s.profile,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var contentType string
+		var count int
+		if err := rows.Scan(&contentType, &count); err != nil {
+			return nil, err
+		}
+		stats.CountsByType[domain.ContentType(contentType)] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if stats.TotalEntries > 0 {
+		if stats.Oldest, err = s.boundaryTimestamp(ctx, "ASC"); err != nil {
+			return nil, err
+		}
+		if stats.Newest, err = s.boundaryTimestamp(ctx, "DESC"); err != nil {
+			return nil, err
+		}
+	}
+
+	if stats.Largest, err = s.largestEntries(ctx, query.Largest); err != nil {
+		return nil, err
+	}
+
+	if stats.Activity, err = s.activity(ctx, query); err != nil {
+		return nil, err
+	}
+
+	if stats.DatabaseBytes, err = s.databaseSize(ctx); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (s *SQLiteStorage) databaseSize(ctx context.Context) (int64, error) {
+	var pageCount, pageSize int64
+	if err := s.reader.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pageCount); err != nil {
+		return 0, err
+	}
+	if err := s.reader.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, err
+	}
+	return pageCount * pageSize, nil
+}
+
+// Maintain checks the database for corruption, refreshes the query planner
+// statistics and returns free pages to the filesystem. A corrupt database
+// is left as it is, since rewriting it could lose more of it; the report
+// lists the problems.
+func (s *SQLiteStorage) Maintain(ctx context.Context) (*domain.MaintenanceReport, error) {
+	report := &domain.MaintenanceReport{StartedAt: time.Now()}
+
+	var err error
+	if report.SizeBefore, err = s.databaseSize(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.writer.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return nil, fmt.Errorf("integrity check failed: %w", err)
+	}
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if result != "ok" {
+			report.IntegrityErrors = append(report.IntegrityErrors, result)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	report.IntegrityOK = len(report.IntegrityErrors) == 0
+	if !report.IntegrityOK {
+		report.SizeAfter = report.SizeBefore
+		report.Duration = time.Since(report.StartedAt)
+		return report, nil
+	}
+
+	if _, err := s.writer.ExecContext(ctx, "ANALYZE"); err != nil {
+		return nil, fmt.Errorf("analyze failed: %w", err)
+	}
+
+	var autoVacuum int
+	if err := s.writer.QueryRowContext(ctx, "PRAGMA auto_vacuum").Scan(&autoVacuum); err != nil {
+		return nil, err
+	}
+
+	const autoVacuumIncremental = 2
+	if autoVacuum != autoVacuumIncremental {
+		if _, err := s.writer.ExecContext(ctx, "PRAGMA auto_vacuum = INCREMENTAL"); err != nil {
+			return nil, err
+		}
+		if _, err := s.writer.ExecContext(ctx, "VACUUM"); err != nil {
+			return nil, fmt.Errorf("vacuum failed: %w", err)
+		}
+		report.FullVacuum = true
+	} else if err := s.incrementalVacuum(ctx); err != nil {
+		return nil, fmt.Errorf("incremental vacuum failed: %w", err)
+	}
+
+	if report.SizeAfter, err = s.databaseSize(ctx); err != nil {
+		return nil, err
+	}
+	report.Duration = time.Since(report.StartedAt)
+
+	return report, nil
+}
+
+func (s *SQLiteStorage) boundaryTimestamp(ctx context.Context, order string) (time.Time, error) {
+	var timestamp time.Time
+	err := s.reader.QueryRowContext(ctx,
+		"SELECT timestamp FROM clipboard_history WHERE profile = ? ORDER BY timestamp "+order+" LIMIT 1",
+		s.profile,
+	).Scan(&timestamp)
+	return timestamp, err
+}
+
+func (s *SQLiteStorage) largestEntries(ctx context.Context, limit int) ([]domain.EntrySize, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	rows, err := s.reader.QueryContext(ctx,
+		"SELECT id, content_type, length(CAST(content AS BLOB)) AS size, timestamp FROM clipboard_history WHERE profile = ? ORDER BY size DESC LIMIT ?",
+		s.profile, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var largest []domain.EntrySize
+
+	for rows.Next() {
+		var id int64
+		var contentType string
+		var size int
+		var timestamp time.Time
+		if err := rows.Scan(&id, &contentType, &size, &timestamp); err != nil {
+			return nil, err
+		}
+		largest = append(largest, domain.EntrySize{
+			Id:        strconv.FormatInt(id, 10),
+			Type:      domain.ContentType(contentType),
+			Size:      size,
+			Timestamp: timestamp,
+		})
+	}
+
+	return largest, rows.Err()
+}
+
+func (s *SQLiteStorage) activity(ctx context.Context, query domain.StatsQuery) ([]domain.ActivityBucket, error) {
+	h := newHistogram(query)
+	if len(h.buckets) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.reader.QueryContext(ctx,
+		"SELECT timestamp FROM clipboard_history WHERE profile = ? AND timestamp >= ? AND timestamp < ?",
+		s.profile, h.start(), query.Until,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var timestamp time.Time
+		if err := rows.Scan(&timestamp); err != nil {
+			return nil, err
+		}
+		h.add(timestamp)
+	}
+
+	return h.buckets, rows.Err()
+}
+
+func contentTypeOrDefault(t domain.ContentType) domain.ContentType {
+	if t == "" {
+		return domain.ContentTypeText
+	}
+	return t
+}
+
+// incrementalVacuum frees one page per step, so the pragma's result rows have
+// to be drained for the whole freelist to be released.
+func (s *SQLiteStorage) incrementalVacuum(ctx context.Context) error {
+	rows, err := s.writer.QueryContext(ctx, "PRAGMA incremental_vacuum")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+	}
+
+	return rows.Err()
+}
+
 func (s *SQLiteStorage) Close() error {
-	return s.db.Close()
+	for _, stmt := range []*sql.Stmt{s.insertStmt, s.getRecentStmt, s.getByIdStmt, s.searchStmt} {
+		if stmt != nil {
+			stmt.Close()
+		}
+	}
+
+	var err error
+	if s.reader != nil {
+		err = s.reader.Close()
+	}
+	return errors.Join(err, s.writer.Close())
 }
@@ -0,0 +1,551 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+func newTestSQLiteStorage(tb testing.TB) *SQLiteStorage {
+	tb.Helper()
+
+	cfg := config.Default().Database
+	cfg.Path = filepath.Join(tb.TempDir(), "clipboard.db")
+
+	s, err := NewSQLiteStorage(cfg)
+	if err != nil {
+		tb.Fatalf("NewSQLiteStorage() error = %v", err)
+	}
+	tb.Cleanup(func() { s.Close() })
+
+	return s
+}
+
+func seedEntries(tb testing.TB, s *SQLiteStorage, n int) {
+	tb.Helper()
+
+	for i := 0; i < n; i++ {
+		_, err := s.Store(context.Background(), &domain.ClipboardEntry{
+			Content:   fmt.Sprintf("seed entry %d with some searchable text", i),
+			Timestamp: time.Now(),
+		})
+		if err != nil {
+			tb.Fatalf("Store() error = %v", err)
+		}
+	}
+}
+
+// startWriter stores an entry every interval until the returned stop function
+// is called, mimicking the monitor loop.
+func startWriter(tb testing.TB, s *SQLiteStorage, interval time.Duration) (stop func() int) {
+	tb.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	var writes int
+	var writeErr error
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_, err := s.Store(ctx, &domain.ClipboardEntry{
+					Content:   fmt.Sprintf("written entry %d", writes),
+					Timestamp: time.Now(),
+				})
+				if err != nil && ctx.Err() == nil {
+					writeErr = err
+					return
+				}
+				writes++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() int {
+		cancel()
+		wg.Wait()
+		if writeErr != nil {
+			tb.Errorf("concurrent Store() error = %v", writeErr)
+		}
+		return writes
+	}
+}
+
+func TestSQLiteStorage_JournalMode(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+
+	var mode string
+	if err := s.reader.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil {
+		t.Fatalf("PRAGMA journal_mode error = %v", err)
+	}
+	if mode != "wal" {
+		t.Errorf("journal_mode = %q, want %q", mode, "wal")
+	}
+
+	if _, err := s.reader.Exec("DELETE FROM clipboard_history"); err == nil {
+		t.Error("reader pool accepted a write, want query_only connection")
+	}
+}
+
+func TestSQLiteStorage_ConcurrentReadWrite(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	seedEntries(t, s, 100)
+
+	stop := startWriter(t, s, time.Millisecond)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				var err error
+				if i%2 == 0 {
+					_, err = s.GetRecent(context.Background(), domain.HistoryFilter{}, 20)
+				} else {
+					_, err = s.Search(context.Background(), "searchable", 20)
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	writes := stop()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent read error = %v", err)
+	}
+
+	if writes == 0 {
+		t.Error("writer made no progress while readers were running")
+	}
+}
+
+func BenchmarkSQLiteStorage_ConcurrentReads(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		read func(ctx context.Context, s *SQLiteStorage) error
+	}{
+		{
+			name: "GetRecent",
+			read: func(ctx context.Context, s *SQLiteStorage) error {
+				_, err := s.GetRecent(ctx, domain.HistoryFilter{}, 50)
+				return err
+			},
+		},
+		{
+			name: "Search",
+			read: func(ctx context.Context, s *SQLiteStorage) error {
+				_, err := s.Search(ctx, "searchable", 50)
+				return err
+			},
+		},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			s := newTestSQLiteStorage(b)
+			seedEntries(b, s, 1000)
+
+			stop := startWriter(b, s, time.Millisecond)
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				ctx := context.Background()
+				for pb.Next() {
+					if err := bm.read(ctx, s); err != nil {
+						b.Errorf("read error = %v", err)
+						return
+					}
+				}
+			})
+			b.StopTimer()
+
+			b.ReportMetric(float64(stop()), "writes")
+		})
+	}
+}
+
+func TestSQLiteStorage_ProfileIsolation(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	ctx := context.Background()
+
+	if _, err := s.CreateProfile(ctx, &domain.Profile{Name: "work"}); err != nil {
+		t.Fatalf("CreateProfile() error = %v", err)
+	}
+
+	work := s.WithProfile("work")
+
+	personalEntry, err := s.Store(ctx, &domain.ClipboardEntry{Content: "personal note", Timestamp: time.Now()})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if _, err := work.Store(ctx, &domain.ClipboardEntry{Content: "work note", Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		storage *SQLiteStorage
+		want    string
+	}{
+		{name: "Default", storage: s, want: "personal note"},
+		{name: "Work", storage: work, want: "work note"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := tt.storage.Search(ctx, "note", 10)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if len(entries) != 1 || entries[0].Content != tt.want {
+				t.Errorf("Search() = %v, want only %q", entries, tt.want)
+			}
+		})
+	}
+
+	if _, err := work.GetById(ctx, personalEntry.Id); err == nil {
+		t.Error("GetById() found an entry from another profile")
+	}
+
+	if err := s.DeleteProfile(ctx, "work"); err != nil {
+		t.Fatalf("DeleteProfile() error = %v", err)
+	}
+	if count, _ := work.Count(ctx); count != 0 {
+		t.Errorf("Count() after DeleteProfile = %d, want 0", count)
+	}
+}
+
+func TestSQLiteStorage_Metadata(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	ctx := context.Background()
+
+	redactions := []domain.Redaction{{Rule: "api_key", Start: 4, End: 25}}
+
+	stored, err := s.Store(ctx, &domain.ClipboardEntry{
+		Content:   "key=«redacted:api_key»",
+		Metadata:  domain.EntryMetadata{Redactions: redactions},
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	plain, err := s.Store(ctx, &domain.ClipboardEntry{Content: "plain", Timestamp: time.Now()})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	got, err := s.GetById(ctx, stored.Id)
+	if err != nil {
+		t.Fatalf("GetById() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Metadata.Redactions, redactions) {
+		t.Errorf("Redactions = %+v, want %+v", got.Metadata.Redactions, redactions)
+	}
+
+	got, err = s.GetById(ctx, plain.Id)
+	if err != nil {
+		t.Fatalf("GetById() error = %v", err)
+	}
+	if len(got.Metadata.Redactions) != 0 {
+		t.Errorf("Redactions = %+v, want none", got.Metadata.Redactions)
+	}
+}
+
+func TestSQLiteStorage_LanguageFilter(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	ctx := context.Background()
+
+	entries := []*domain.ClipboardEntry{
+		{Content: "package main", Type: domain.ContentTypeCode, Language: "go"},
+		{Content: "hello", Type: domain.ContentTypeText},
+		{Content: "def f(): pass", Type: domain.ContentTypeCode, Language: "python"},
+		{Content: "func f() {}", Type: domain.ContentTypeCode, Language: "go"},
+	}
+	for i, entry := range entries {
+		entry.Timestamp = time.Now().Add(time.Duration(i) * time.Second)
+		if _, err := s.Store(ctx, entry); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		language string
+		want     []string
+	}{
+		{language: "", want: []string{"func f() {}", "def f(): pass", "hello", "package main"}},
+		{language: "go", want: []string{"func f() {}", "package main"}},
+		{language: "python", want: []string{"def f(): pass"}},
+		{language: "rust", want: nil},
+	}
+
+	for _, tt := range tests {
+		got, err := s.GetRecent(ctx, domain.HistoryFilter{Language: tt.language}, 10)
+		if err != nil {
+			t.Fatalf("GetRecent(%q) error = %v", tt.language, err)
+		}
+
+		var contents []string
+		for _, entry := range got {
+			contents = append(contents, entry.Content)
+			if tt.language != "" && entry.Language != tt.language {
+				t.Errorf("GetRecent(%q) returned entry with language %q", tt.language, entry.Language)
+			}
+		}
+		if !reflect.DeepEqual(contents, tt.want) {
+			t.Errorf("GetRecent(%q) = %v, want %v", tt.language, contents, tt.want)
+		}
+	}
+}
+
+func TestSQLiteStorage_DomainFilter(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	ctx := context.Background()
+
+	entries := []*domain.ClipboardEntry{
+		{Content: "https://github.com/", Type: domain.ContentTypeURL, Domain: "github.com"},
+		{Content: "hello", Type: domain.ContentTypeText},
+		{Content: "https://gist.github.com/x", Type: domain.ContentTypeURL, Domain: "gist.github.com"},
+		{Content: "https://notgithub.com/", Type: domain.ContentTypeURL, Domain: "notgithub.com"},
+		{Content: "https://a_b.example.com/", Type: domain.ContentTypeURL, Domain: "a_b.example.com"},
+	}
+	for i, entry := range entries {
+		entry.Timestamp = time.Now().Add(time.Duration(i) * time.Second)
+		if _, err := s.Store(ctx, entry); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		domain string
+		want   []string
+	}{
+		{domain: "github.com", want: []string{"https://gist.github.com/x", "https://github.com/"}},
+		{domain: "gist.github.com", want: []string{"https://gist.github.com/x"}},
+		{domain: "example.com", want: []string{"https://a_b.example.com/"}},
+		{domain: "gitlab.com", want: nil},
+		{domain: "%", want: nil},
+		{domain: "_", want: nil},
+		{domain: "%.com", want: nil},
+		{domain: "github.co_", want: nil},
+		{domain: "a_b.example.com", want: []string{"https://a_b.example.com/"}},
+	}
+
+	for _, tt := range tests {
+		got, err := s.GetRecent(ctx, domain.HistoryFilter{Domain: tt.domain}, 10)
+		if err != nil {
+			t.Fatalf("GetRecent(%q) error = %v", tt.domain, err)
+		}
+
+		var contents []string
+		for _, entry := range got {
+			contents = append(contents, entry.Content)
+		}
+		if !reflect.DeepEqual(contents, tt.want) {
+			t.Errorf("GetRecent(%q) = %v, want %v", tt.domain, contents, tt.want)
+		}
+	}
+}
+
+func TestSQLiteStorage_DeleteExpired(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	other := s.WithProfile("work")
+	ctx := context.Background()
+	now := time.Now()
+
+	expired, err := s.Store(ctx, &domain.ClipboardEntry{Content: "expired", Timestamp: now, ExpiresAt: now.Add(-time.Second)})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	pending, err := s.Store(ctx, &domain.ClipboardEntry{Content: "pending", Timestamp: now, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	kept, err := s.Store(ctx, &domain.ClipboardEntry{Content: "kept", Timestamp: now})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if _, err := other.Store(ctx, &domain.ClipboardEntry{Content: "other profile", Timestamp: now, ExpiresAt: now.Add(-time.Second)}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	deleted, err := s.DeleteExpired(ctx, now)
+	if err != nil {
+		t.Fatalf("DeleteExpired() error = %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("DeleteExpired() = %d, want 1", deleted)
+	}
+
+	if _, err := s.GetById(ctx, expired.Id); err == nil {
+		t.Error("expected expired entry to be deleted")
+	}
+	got, err := s.GetById(ctx, pending.Id)
+	if err != nil {
+		t.Fatalf("GetById() error = %v", err)
+	}
+	if !got.ExpiresAt.Equal(pending.ExpiresAt) {
+		t.Errorf("ExpiresAt = %s, want %s", got.ExpiresAt, pending.ExpiresAt)
+	}
+	got, err = s.GetById(ctx, kept.Id)
+	if err != nil {
+		t.Fatalf("GetById() error = %v", err)
+	}
+	if !got.ExpiresAt.IsZero() {
+		t.Errorf("ExpiresAt = %s, want zero", got.ExpiresAt)
+	}
+
+	if count, err := other.Count(ctx); err != nil || count != 1 {
+		t.Errorf("expected other profile to keep its entry, got %d (%v)", count, err)
+	}
+}
+
+func TestSQLiteStorage_MarkUsed(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	other := s.WithProfile("work")
+	ctx := context.Background()
+
+	entry, err := s.Store(ctx, &domain.ClipboardEntry{Content: "copy me", Timestamp: time.Now()})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if entry.UseCount != 0 || !entry.LastUsedAt.IsZero() {
+		t.Errorf("new entry has usage %d at %s, want none", entry.UseCount, entry.LastUsedAt)
+	}
+
+	first := time.Now().Add(-time.Minute)
+	last := time.Now()
+	if err := s.MarkUsed(ctx, entry.Id, first); err != nil {
+		t.Fatalf("MarkUsed() error = %v", err)
+	}
+	if err := s.MarkUsed(ctx, entry.Id, last); err != nil {
+		t.Fatalf("MarkUsed() error = %v", err)
+	}
+
+	got, err := s.GetById(ctx, entry.Id)
+	if err != nil {
+		t.Fatalf("GetById() error = %v", err)
+	}
+	if got.UseCount != 2 {
+		t.Errorf("UseCount = %d, want 2", got.UseCount)
+	}
+	if !got.LastUsedAt.Equal(last) {
+		t.Errorf("LastUsedAt = %s, want %s", got.LastUsedAt, last)
+	}
+
+	if err := other.MarkUsed(ctx, entry.Id, last); err == nil {
+		t.Error("MarkUsed() updated an entry from another profile")
+	}
+	if err := s.MarkUsed(ctx, "999", last); err == nil {
+		t.Error("MarkUsed() expected an error for a missing entry")
+	}
+}
+
+func TestSQLiteStorage_Pause(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	ctx := context.Background()
+
+	state, err := s.LoadPause(ctx)
+	if err != nil {
+		t.Fatalf("LoadPause() error = %v", err)
+	}
+	if state.Paused {
+		t.Errorf("LoadPause() = %+v, want not paused", state)
+	}
+
+	tests := []struct {
+		name  string
+		state domain.PauseState
+	}{
+		{name: "timed", state: domain.PauseState{Paused: true, Since: time.Now(), Until: time.Now().Add(10 * time.Minute)}},
+		{name: "until resumed", state: domain.PauseState{Paused: true, Since: time.Now()}},
+		{name: "resumed", state: domain.PauseState{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.SavePause(ctx, tt.state); err != nil {
+				t.Fatalf("SavePause() error = %v", err)
+			}
+			got, err := s.LoadPause(ctx)
+			if err != nil {
+				t.Fatalf("LoadPause() error = %v", err)
+			}
+			if got.Paused != tt.state.Paused || !got.Since.Equal(tt.state.Since) || !got.Until.Equal(tt.state.Until) {
+				t.Errorf("LoadPause() = %+v, want %+v", got, tt.state)
+			}
+		})
+	}
+}
+
+func TestSQLiteStorage_Maintain(t *testing.T) {
+	s := newTestSQLiteStorage(t)
+	ctx := context.Background()
+
+	// fill stores large entries and deletes them, leaving free pages.
+	fill := func() {
+		t.Helper()
+		for i := 0; i < 200; i++ {
+			_, err := s.Store(ctx, &domain.ClipboardEntry{
+				Content:   fmt.Sprintf("%d %s", i, strings.Repeat("x", 8<<10)),
+				Timestamp: time.Now(),
+			})
+			if err != nil {
+				t.Fatalf("Store() error = %v", err)
+			}
+		}
+		if _, err := s.DeleteOlderThan(ctx, time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("DeleteOlderThan() error = %v", err)
+		}
+	}
+
+	// A database created before incremental auto-vacuum gets a full VACUUM
+	// first, and incremental ones after that.
+	if _, err := s.writer.ExecContext(ctx, "PRAGMA auto_vacuum = NONE"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.writer.ExecContext(ctx, "VACUUM"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, wantFull := range []bool{true, false} {
+		fill()
+
+		report, err := s.Maintain(ctx)
+		if err != nil {
+			t.Fatalf("Maintain() error = %v", err)
+		}
+		if !report.IntegrityOK || len(report.IntegrityErrors) > 0 {
+			t.Errorf("Maintain() integrity = %v %v, want ok", report.IntegrityOK, report.IntegrityErrors)
+		}
+		if report.FullVacuum != wantFull {
+			t.Errorf("Maintain() FullVacuum = %v, want %v", report.FullVacuum, wantFull)
+		}
+		if report.SizeAfter >= report.SizeBefore {
+			t.Errorf("Maintain() size %d -> %d, want space reclaimed", report.SizeBefore, report.SizeAfter)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"sort"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+const day = 24 * time.Hour
+
+// histogram counts timestamps into consecutive buckets covering a stats window.
+type histogram struct {
+	buckets []domain.ActivityBucket
+	until   time.Time
+}
+
+func newHistogram(q domain.StatsQuery) *histogram {
+	h := &histogram{until: q.Until}
+	if q.Bucket <= 0 || !q.Until.After(q.Since) {
+		return h
+	}
+
+	for t := alignBucket(q.Since, q.Bucket); t.Before(q.Until); t = nextBucket(t, q.Bucket) {
+		h.buckets = append(h.buckets, domain.ActivityBucket{Start: t})
+	}
+
+	return h
+}
+
+// start returns the beginning of the first bucket, which may precede the
+// requested window because buckets are aligned to whole hours or days.
+func (h *histogram) start() time.Time {
+	if len(h.buckets) == 0 {
+		return h.until
+	}
+	return h.buckets[0].Start
+}
+
+func (h *histogram) add(t time.Time) {
+	if !t.Before(h.until) {
+		return
+	}
+
+	i := sort.Search(len(h.buckets), func(i int) bool {
+		return h.buckets[i].Start.After(t)
+	}) - 1
+
+	if i >= 0 {
+		h.buckets[i].Count++
+	}
+}
+
+// alignBucket snaps t to the start of its bucket. Daily buckets follow local
+// midnight so that they line up with calendar days.
+func alignBucket(t time.Time, bucket time.Duration) time.Time {
+	if bucket == day {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+	return t.Truncate(bucket)
+}
+
+func nextBucket(t time.Time, bucket time.Duration) time.Time {
+	if bucket == day {
+		return t.AddDate(0, 0, 1)
+	}
+	return t.Add(bucket)
+}
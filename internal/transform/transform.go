@@ -0,0 +1,167 @@
+// Package transform holds the named content transforms that can be applied
+// to clipboard entries, alone or chained.
+package transform
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrUnknown = errors.New("unknown transform")
+
+type Transform struct {
+	Name        string
+	Description string
+	Apply       func(content string) (string, error)
+}
+
+// transforms are listed in the order List returns them.
+var transforms = []Transform{
+	{"trim", "Remove leading and trailing whitespace", infallible(strings.TrimSpace)},
+	{"upper", "Convert to upper case", infallible(strings.ToUpper)},
+	{"lower", "Convert to lower case", infallible(strings.ToLower)},
+	{"title", "Capitalise the first letter of every word", infallible(titleCase)},
+	{"base64_encode", "Encode as standard base64", infallible(func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	})},
+	{"base64_decode", "Decode standard or URL-safe base64, padded or not", base64Decode},
+	{"url_encode", "Percent-encode for use in a query string", infallible(url.QueryEscape)},
+	{"url_decode", "Decode percent-encoding", url.QueryUnescape},
+	{"json_pretty", "Indent JSON with two spaces", jsonPretty},
+	{"json_minify", "Remove insignificant whitespace from JSON", jsonMinify},
+	{"sort_lines", "Sort lines", lines(func(l []string) []string {
+		slices.Sort(l)
+		return l
+	})},
+	{"dedupe_lines", "Remove repeated lines, keeping the first", lines(dedupe)},
+	{"json_string", "Escape as a JSON string literal", jsonString},
+	{"go_string", "Escape as a Go string literal", infallible(strconv.Quote)},
+	{"shell_quote", "Quote as a single POSIX shell word", infallible(shellQuote)},
+}
+
+// List returns every transform.
+func List() []Transform {
+	return slices.Clone(transforms)
+}
+
+func Lookup(name string) (Transform, bool) {
+	for _, t := range transforms {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Transform{}, false
+}
+
+// Chain applies the named transforms in order. Every name is checked before
+// anything runs.
+func Chain(content string, names []string) (string, error) {
+	chain := make([]Transform, 0, len(names))
+	for _, name := range names {
+		t, ok := Lookup(name)
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrUnknown, name)
+		}
+		chain = append(chain, t)
+	}
+
+	for _, t := range chain {
+		var err error
+		if content, err = t.Apply(content); err != nil {
+			return "", fmt.Errorf("%s: %w", t.Name, err)
+		}
+	}
+	return content, nil
+}
+
+func infallible(f func(string) string) func(string) (string, error) {
+	return func(s string) (string, error) {
+		return f(s), nil
+	}
+}
+
+// lines applies f to the lines of content, keeping a trailing newline.
+func lines(f func([]string) []string) func(string) (string, error) {
+	return func(s string) (string, error) {
+		body, trailing := strings.CutSuffix(s, "\n")
+		out := strings.Join(f(strings.Split(body, "\n")), "\n")
+		if trailing {
+			out += "\n"
+		}
+		return out, nil
+	}
+}
+
+func dedupe(lines []string) []string {
+	seen := make(map[string]bool, len(lines))
+	out := lines[:0]
+	for _, line := range lines {
+		if !seen[line] {
+			seen[line] = true
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+func titleCase(s string) string {
+	runes := []rune(s)
+	start := true
+	for i, r := range runes {
+		if start && unicode.IsLetter(r) {
+			runes[i] = unicode.ToUpper(r)
+		}
+		start = unicode.IsSpace(r) || r == '-' || r == '_'
+	}
+	return string(runes)
+}
+
+func base64Decode(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding,
+	} {
+		if decoded, err := enc.DecodeString(s); err == nil {
+			return string(decoded), nil
+		}
+	}
+	return "", errors.New("not valid base64")
+}
+
+func jsonPretty(s string) (string, error) {
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(s), "", "  "); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func jsonMinify(s string) (string, error) {
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(s)); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func jsonString(s string) (string, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,110 @@
+package transform
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTransforms(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{name: "trim", content: "  hi \n", want: "hi"},
+		{name: "upper", content: "Hello", want: "HELLO"},
+		{name: "lower", content: "Hello", want: "hello"},
+		{name: "title", content: "hello wide-world of_go", want: "Hello Wide-World Of_Go"},
+		{name: "base64_encode", content: "hi?>", want: "aGk/Pg=="},
+		{name: "base64_decode", content: "aGk/Pg==", want: "hi?>"},
+		{name: "base64_decode", content: "aGk_Pg", want: "hi?>"},
+		{name: "base64_decode", content: "not base64!", wantErr: true},
+		{name: "url_encode", content: "a b&c=d", want: "a+b%26c%3Dd"},
+		{name: "url_decode", content: "a+b%26c%3Dd", want: "a b&c=d"},
+		{name: "url_decode", content: "%zz", wantErr: true},
+		{name: "json_pretty", content: `{"a":[1,2]}`, want: "{\n  \"a\": [\n    1,\n    2\n  ]\n}"},
+		{name: "json_pretty", content: `{"a":`, wantErr: true},
+		{name: "json_minify", content: "{\n  \"a\": 1\n}", want: `{"a":1}`},
+		{name: "sort_lines", content: "b\na\nc\n", want: "a\nb\nc\n"},
+		{name: "dedupe_lines", content: "b\na\nb\na", want: "b\na"},
+		{name: "json_string", content: "say \"<hi>\"\n", want: `"say \"<hi>\"\n"`},
+		{name: "go_string", content: "tab\there", want: `"tab\there"`},
+		{name: "shell_quote", content: "it's here", want: `'it'\''s here'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, ok := Lookup(tt.name)
+			if !ok {
+				t.Fatalf("transform %q not registered", tt.name)
+			}
+
+			got, err := tr.Apply(tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("%s(%q) expected an error, got %q", tt.name, tt.content, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s(%q) error = %v", tt.name, tt.content, err)
+			}
+			if got != tt.want {
+				t.Errorf("%s(%q) = %q, want %q", tt.name, tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		names   []string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "in order",
+			content: "  c\na\nc  ",
+			names:   []string{"trim", "dedupe_lines", "sort_lines", "upper"},
+			want:    "A\nC",
+		},
+		{
+			name:    "round trip",
+			content: "héllo",
+			names:   []string{"base64_encode", "base64_decode"},
+			want:    "héllo",
+		},
+		{
+			name:    "unknown transform runs nothing",
+			content: "x",
+			names:   []string{"upper", "reverse"},
+			wantErr: ErrUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Chain(tt.content, tt.names)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Chain() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Chain("{", []string{"trim", "json_pretty"}); err == nil || !strings.HasPrefix(err.Error(), "json_pretty:") {
+		t.Errorf("expected the failing transform to be named, got %v", err)
+	}
+}
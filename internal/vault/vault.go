@@ -0,0 +1,157 @@
+// Package vault keeps sensitive clipboard entries in memory for a short time
+// so they can be pasted again, without ever writing them to disk.
+package vault
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+type item struct {
+	entry   domain.VaultEntry
+	content []byte
+	timer   *time.Timer
+}
+
+type Vault struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu     sync.Mutex
+	items  []*item // oldest first
+	nextId int
+}
+
+func New(cfg config.VaultConfig) *Vault {
+	return &Vault{
+		ttl:        cfg.TTL,
+		maxEntries: cfg.MaxEntries,
+	}
+}
+
+func (v *Vault) TTL() time.Duration {
+	return v.ttl
+}
+
+// Put stores content until the TTL runs out. Putting content that is
+// already held returns the existing entry without extending its lifetime.
+func (v *Vault) Put(entry *domain.ClipboardEntry, analysis *domain.Analysis) domain.VaultEntry {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, it := range v.items {
+		if string(it.content) == entry.Content {
+			return it.entry
+		}
+	}
+
+	if v.maxEntries > 0 && len(v.items) >= v.maxEntries {
+		v.removeLocked(v.items[0])
+	}
+
+	v.nextId++
+	now := time.Now()
+	it := &item{
+		entry: domain.VaultEntry{
+			Id:        "v" + strconv.Itoa(v.nextId),
+			Preview:   mask(entry.Content),
+			Length:    utf8.RuneCountInString(entry.Content),
+			Type:      analysis.Type,
+			Reason:    analysis.Reason,
+			Severity:  analysis.Severity,
+			CreatedAt: now,
+			ExpiresAt: now.Add(v.ttl),
+		},
+		content: []byte(entry.Content),
+	}
+	it.timer = time.AfterFunc(v.ttl, func() {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		v.removeLocked(it)
+	})
+	v.items = append(v.items, it)
+
+	return it.entry
+}
+
+// List returns the held entries, newest first.
+func (v *Vault) List() []domain.VaultEntry {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	entries := make([]domain.VaultEntry, 0, len(v.items))
+	for i := len(v.items) - 1; i >= 0; i-- {
+		entries = append(entries, v.items[i].entry)
+	}
+	return entries
+}
+
+// Reveal returns the content of an entry that has not expired yet.
+func (v *Vault) Reveal(id string) (domain.VaultEntry, string, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, it := range v.items {
+		if it.entry.Id == id {
+			return it.entry, string(it.content), true
+		}
+	}
+	return domain.VaultEntry{}, "", false
+}
+
+func (v *Vault) Delete(id string) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, it := range v.items {
+		if it.entry.Id == id {
+			v.removeLocked(it)
+			return true
+		}
+	}
+	return false
+}
+
+// Purge zeroes and drops every entry. It returns how many were held.
+func (v *Vault) Purge() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	n := len(v.items)
+	for len(v.items) > 0 {
+		v.removeLocked(v.items[0])
+	}
+	return n
+}
+
+// removeLocked zeroes the content of it and drops it. It is a no-op when it
+// was already removed, so an expiry racing a delete is harmless.
+func (v *Vault) removeLocked(it *item) {
+	for i, other := range v.items {
+		if other != it {
+			continue
+		}
+
+		it.timer.Stop()
+		clear(it.content)
+		it.content = nil
+		v.items = append(v.items[:i], v.items[i+1:]...)
+		return
+	}
+}
+
+// mask keeps the first and last two characters of long single-line content
+// and hides everything else.
+func mask(content string) string {
+	runes := []rune(strings.TrimSpace(content))
+	if len(runes) < 12 || strings.ContainsRune(string(runes), '\n') {
+		return strings.Repeat("•", min(len(runes), 8))
+	}
+	return string(runes[:2]) + strings.Repeat("•", 8) + string(runes[len(runes)-2:])
+}
@@ -0,0 +1,118 @@
+package vault
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/geodask/clipboard-manager/internal/config"
+	"github.com/geodask/clipboard-manager/internal/domain"
+)
+
+func newTestVault(ttl time.Duration, maxEntries int) *Vault {
+	return New(config.VaultConfig{Enabled: true, TTL: ttl, MaxEntries: maxEntries})
+}
+
+func put(v *Vault, content string) domain.VaultEntry {
+	return v.Put(&domain.ClipboardEntry{Content: content}, &domain.Analysis{
+		Type:     domain.ContentTypeText,
+		Reason:   "password",
+		Severity: domain.SeverityHigh,
+	})
+}
+
+func TestVaultPutAndReveal(t *testing.T) {
+	v := newTestVault(time.Minute, 10)
+
+	first := put(v, "password=hunter2")
+	second := put(v, "token: abcdefghijklmnopqrstuvwxyz")
+
+	if first.Id == second.Id {
+		t.Fatalf("expected distinct ids, got %q twice", first.Id)
+	}
+	if first.Reason != "password" || first.Length != 16 {
+		t.Errorf("unexpected entry %+v", first)
+	}
+
+	if again := put(v, "password=hunter2"); again.Id != first.Id || !again.ExpiresAt.Equal(first.ExpiresAt) {
+		t.Errorf("expected duplicate content to return the existing entry, got %+v", again)
+	}
+
+	list := v.List()
+	if len(list) != 2 || list[0].Id != second.Id {
+		t.Fatalf("expected 2 entries newest first, got %+v", list)
+	}
+
+	_, content, ok := v.Reveal(first.Id)
+	if !ok || content != "password=hunter2" {
+		t.Errorf("Reveal() = %q, %v", content, ok)
+	}
+	if _, _, ok := v.Reveal("v99"); ok {
+		t.Error("expected unknown id to be missing")
+	}
+}
+
+func TestVaultExpiryZeroesContent(t *testing.T) {
+	v := newTestVault(20*time.Millisecond, 10)
+
+	entry := put(v, "password=hunter2")
+	held := v.items[0].content
+
+	deadline := time.Now().Add(time.Second)
+	for len(v.List()) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("entry did not expire")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if _, _, ok := v.Reveal(entry.Id); ok {
+		t.Error("expected expired entry to be gone")
+	}
+	if !bytes.Equal(held, make([]byte, len(held))) {
+		t.Errorf("expected content to be zeroed, got %q", held)
+	}
+}
+
+func TestVaultPurgeAndEviction(t *testing.T) {
+	v := newTestVault(time.Minute, 2)
+
+	oldest := put(v, "secret one")
+	put(v, "secret two")
+	put(v, "secret three")
+
+	if _, _, ok := v.Reveal(oldest.Id); ok {
+		t.Error("expected oldest entry to be evicted at capacity")
+	}
+
+	held := [][]byte{v.items[0].content, v.items[1].content}
+	if n := v.Purge(); n != 2 {
+		t.Errorf("Purge() = %d, want 2", n)
+	}
+	if len(v.List()) != 0 {
+		t.Error("expected vault to be empty after purge")
+	}
+	for _, content := range held {
+		if !bytes.Equal(content, make([]byte, len(content))) {
+			t.Errorf("expected content to be zeroed, got %q", content)
+		}
+	}
+}
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{content: "hunter2", want: "•••••••"},
+		{content: "correct horse battery", want: "co••••••••ry"},
+		{content: "  wJalrXUtnFEMI/K7MDENG  ", want: "wJ••••••••NG"},
+		{content: "line one\nline two", want: "••••••••"},
+	}
+
+	for _, tt := range tests {
+		if got := mask(tt.content); got != tt.want {
+			t.Errorf("mask(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}